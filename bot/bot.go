@@ -4,17 +4,21 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strconv"
 	"strings"
+	"time"
 
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
 	"github.com/eugenepelipets/window-wash-bot/models"
 	"github.com/eugenepelipets/window-wash-bot/storage"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type Bot struct {
-	api *tgbotapi.BotAPI
-	db  *storage.Postgres
+	api        *tgbotapi.BotAPI
+	db         *storage.Postgres
+	broadcasts chan broadcastJob
+	startedAt  time.Time // рассылки, поставленные в очередь раньше, прерваны прошлым запуском
 }
 
 // Создаем бота
@@ -33,7 +37,7 @@ func NewBot(db *storage.Postgres) (*Bot, error) {
 
 	log.Printf("✅ Бот авторизован как %s", bot.Self.UserName)
 
-	return &Bot{api: bot, db: db}, nil
+	return &Bot{api: bot, db: db, broadcasts: make(chan broadcastJob, 16), startedAt: clock.Now()}, nil
 }
 
 // Запуск бота
@@ -43,22 +47,45 @@ func (b *Bot) Start() {
 
 	updates := b.api.GetUpdatesChan(u)
 
+	// Очередь рассылок обрабатывается в фоне
+	go b.broadcastWorker()
+	go b.resumeBroadcasts()
+
+	// Планировщик автоматических отчетов
+	go b.runScheduler()
+
 	for update := range updates {
 		if update.Message != nil {
+			b.detectLanguage(update.Message.Chat.ID, update.Message.From)
+
 			// Обрабатываем текстовые сообщения
 			if update.Message.IsCommand() {
 				b.handleMessage(update.Message)
+			} else if _, ok := adminInputs[update.Message.Chat.ID]; ok {
+				// Ввод по заказу из карточки администратора не прерывает его собственный диалог заказа
+				b.handleAdminText(update.Message)
 			} else {
 				// Проверяем текущее состояние пользователя
 				session := b.getSession(update.Message.Chat.ID)
 				switch session.CurrentState {
-				case StateWaitingForFloor, StateWaitingForApartment, StateTelegramNick:
+				case StateWaitingForFloor, StateWaitingForApartment, StateTelegramNick,
+					StateCustomerName, StateCustomerPhone, StateContactPhone,
+					StateWindowsSameCount, StateWindowsDifferent, StateOrderNotes, StateIntercomCode:
 					b.handleTextMessage(update.Message)
+				case StateQuoteDetails:
+					b.handleQuoteMessage(update.Message)
+				case StateAdminBroadcastText:
+					b.handleBroadcastText(update.Message)
+				case StateAdminImport:
+					b.handleImportDocument(update.Message)
 				default:
-					b.sendMessage(update.Message.Chat.ID, "Пожалуйста, используйте кнопки для продолжения.")
+					b.sendMessage(update.Message.Chat.ID, b.t(update.Message.Chat.ID, "use_buttons"))
 				}
 			}
 		} else if update.CallbackQuery != nil {
+			if update.CallbackQuery.Message != nil {
+				b.detectLanguage(update.CallbackQuery.Message.Chat.ID, update.CallbackQuery.From)
+			}
 			b.handleCallback(update.CallbackQuery)
 		}
 	}
@@ -69,10 +96,26 @@ func (b *Bot) handleMessage(msg *tgbotapi.Message) {
 	switch {
 	case msg.Text == "/start":
 		b.handleStart(msg)
+	case strings.HasPrefix(msg.Text, "/language"):
+		b.handleLanguage(msg)
 	case strings.HasPrefix(msg.Text, "/export"):
 		b.handleExport(msg)
+	case strings.HasPrefix(msg.Text, "/broadcast"):
+		b.handleBroadcast(msg)
+	case strings.HasPrefix(msg.Text, "/neworder"):
+		b.handlePhoneOrder(msg)
+	case strings.HasPrefix(msg.Text, "/import"):
+		b.handleImport(msg)
+	case strings.HasPrefix(msg.Text, "/jobs"):
+		b.handleJobs(msg)
+	case strings.HasPrefix(msg.Text, "/find"):
+		b.handleFind(msg)
+	case strings.HasPrefix(msg.Text, "/stats"):
+		b.handleStats(msg)
+	case strings.HasPrefix(msg.Text, "/staff"):
+		b.handleStaff(msg)
 	default:
-		b.sendMessage(msg.Chat.ID, "Я не понимаю эту команду 🤔")
+		b.sendMessage(msg.Chat.ID, b.t(msg.Chat.ID, "command.unknown"))
 	}
 }
 
@@ -83,6 +126,7 @@ func (b *Bot) handleStart(msg *tgbotapi.Message) {
 		UserName:   msg.From.UserName,
 		FirstName:  msg.From.FirstName,
 		LastName:   msg.From.LastName,
+		Language:   b.lang(msg.Chat.ID),
 	}
 
 	err := b.db.SaveUser(user)
@@ -90,13 +134,23 @@ func (b *Bot) handleStart(msg *tgbotapi.Message) {
 		log.Printf("⚠️ Ошибка сохранения пользователя: %v", err)
 	}
 
+	// Кнопки незавершенного заказа больше не нужны — приветствие начинает диалог заново
+	b.supersedeCard(msg.Chat.ID)
+
+	// Вернувшимся клиентам предлагаем повторить прошлый заказ
+	lastOrder, err := b.db.GetLastOrder(msg.Chat.ID)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	lang := b.lang(msg.Chat.ID)
+	if lastOrder != nil {
+		b.sendMessage(msg.Chat.ID, i18n.T(lang, "start.welcome_back", lastOrder.Entrance, lastOrder.Floor, lastOrder.Apartment),
+			createStartKeyboard(lang, true))
+		return
+	}
+
 	// Отправляем приветственное сообщение с кнопкой
-	replyMarkup := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Новый заказ", "new_order"),
-		),
-	)
-	b.sendMessage(msg.Chat.ID, "Привет! Чтобы записаться на мойку окон нажми на кнопку 👇", replyMarkup)
+	b.sendMessage(msg.Chat.ID, i18n.T(lang, "start.welcome"), createStartKeyboard(lang, false))
 }
 
 func (b *Bot) sendMessage(chatID int64, text string, replyMarkup ...tgbotapi.InlineKeyboardMarkup) {
@@ -109,32 +163,20 @@ func (b *Bot) sendMessage(chatID int64, text string, replyMarkup ...tgbotapi.Inl
 	}
 }
 
-func (b *Bot) sendMainMenu(chatID int64) {
-	msg := tgbotapi.NewMessage(chatID, "Главное меню:")
-	msg.ReplyMarkup = createMainMenuKeyboard()
-	b.api.Send(msg)
-}
-
-func (b *Bot) sendEntranceKeyboard(chatID int64) {
-	msg := tgbotapi.NewMessage(chatID, "Выберите подъезд:")
-	msg.ReplyMarkup = createEntranceKeyboard()
-	b.api.Send(msg)
+// showMainMenuCard показывает главное меню в карточке заказа и завершает диалог
+func (b *Bot) showMainMenuCard(chatID int64) {
+	b.showCard(chatID, b.t(chatID, "main_menu"), createMainMenuKeyboard(b.lang(chatID)))
+	delete(userSessions, chatID)
 }
 
 func (b *Bot) notifyAdminAboutDuplicate(chatID int64, order models.Order) {
-	adminID, _ := strconv.ParseInt(os.Getenv("ADMIN_TELEGRAM_ID"), 10, 64)
-	if adminID == 0 {
-		return
-	}
-
 	msgText := fmt.Sprintf(
 		"⚠️ Обнаружен дублирующий заказ!\n\n"+
 			"Подъезд: %d\nЭтаж: %d\nКвартира: %s\n"+
-			"Пользователь: @%s (%d)\n\n"+
+			"Клиент: %s\n\n"+
 			"Первый заказ будет подтвержден, этот - на уточнении.",
 		order.Entrance, order.Floor, order.Apartment,
-		order.User.UserName, order.User.TelegramID)
+		customerContact(order))
 
-	msg := tgbotapi.NewMessage(adminID, msgText)
-	b.api.Send(msg)
+	b.notifyStaff(NotifyDuplicateOrder, msgText)
 }
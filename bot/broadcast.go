@@ -0,0 +1,303 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const StateAdminBroadcastText = "admin_broadcast_text"
+
+// broadcastInterval — пауза между сообщениями рассылки.
+// Telegram допускает около 30 сообщений в секунду, оставляем запас.
+const broadcastInterval = 50 * time.Millisecond
+
+// broadcastMaxRetries — сколько раз повторять отправку после ответа 429
+const broadcastMaxRetries = 5
+
+// broadcastJob — рассылка, поставленная в очередь на отправку
+type broadcastJob struct {
+	ID         int64
+	AdminID    int64
+	Text       string
+	Recipients []int64
+}
+
+// handleBroadcast обрабатывает команду /broadcast — начинает выбор аудитории
+func (b *Bot) handleBroadcast(msg *tgbotapi.Message) {
+	if !b.requirePermission(msg.Chat.ID, PermBroadcast) {
+		return
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Все пользователи", "bc_aud_all"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Все клиенты дома", "bc_aud_customers"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Подъезд", "bc_aud_entrance"),
+			tgbotapi.NewInlineKeyboardButtonData("Статус заказа", "bc_aud_status"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Без заказа в этом сезоне", "bc_aud_no_orders"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Отмена", "bc_cancel"),
+		),
+	)
+	b.sendMessage(msg.Chat.ID, "Кому отправить рассылку?", markup)
+}
+
+// handleBroadcastCallback обрабатывает кнопки мастера рассылки
+func (b *Bot) handleBroadcastCallback(chatID int64, data string) {
+	if !b.requirePermission(chatID, PermBroadcast) {
+		return
+	}
+	session := b.getSession(chatID)
+
+	switch {
+	case data == "bc_aud_entrance":
+		var row []tgbotapi.InlineKeyboardButton
+		for entrance := 1; entrance <= maxEntrance; entrance++ {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+				strconv.Itoa(entrance), fmt.Sprintf("bc_ent_%d", entrance)))
+		}
+		b.sendMessage(chatID, "Выберите подъезд:", tgbotapi.NewInlineKeyboardMarkup(row))
+
+	case data == "bc_aud_status":
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for _, status := range []string{"confirmed", "needs_clarification", "awaiting_quote", "canceled"} {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(i18n.Default, "status."+status), "bc_st_"+status)))
+		}
+		b.sendMessage(chatID, "Выберите статус заказа:", tgbotapi.NewInlineKeyboardMarkup(rows...))
+
+	case strings.HasPrefix(data, "bc_aud_"):
+		kind := data[len("bc_aud_"):]
+		switch kind {
+		case models.AudienceAll, models.AudienceCustomers:
+			b.askBroadcastText(chatID, models.BroadcastAudience{Kind: kind})
+		case models.AudienceNoOrders:
+			now := clock.Now()
+			b.askBroadcastText(chatID, models.BroadcastAudience{
+				Kind:        kind,
+				SeasonStart: time.Date(now.Year(), 1, 1, 0, 0, 0, 0, clock.Location()),
+			})
+		}
+
+	case strings.HasPrefix(data, "bc_ent_"):
+		entrance, err := strconv.Atoi(data[len("bc_ent_"):])
+		if err != nil || entrance < 1 || entrance > maxEntrance {
+			return
+		}
+		b.askBroadcastText(chatID, models.BroadcastAudience{Kind: models.AudienceEntrance, Entrance: entrance})
+
+	case strings.HasPrefix(data, "bc_st_"):
+		status := data[len("bc_st_"):]
+		if !orderStatuses[status] {
+			return
+		}
+		b.askBroadcastText(chatID, models.BroadcastAudience{Kind: models.AudienceStatus, Status: status})
+
+	case data == "bc_send":
+		audience, ok1 := session.TempData["broadcast_audience"].(models.BroadcastAudience)
+		text, ok2 := session.TempData["broadcast_text"].(string)
+		if !ok1 || !ok2 {
+			b.sendMessage(chatID, "Рассылка не найдена. Начните заново: /broadcast")
+			return
+		}
+		delete(session.TempData, "broadcast_audience")
+		delete(session.TempData, "broadcast_text")
+		b.enqueueBroadcast(chatID, audience, text)
+
+	case data == "bc_cancel":
+		delete(session.TempData, "broadcast_audience")
+		delete(session.TempData, "broadcast_text")
+		if session.CurrentState == StateAdminBroadcastText {
+			session.CurrentState = StateDefault
+		}
+		b.sendMessage(chatID, "Рассылка отменена.")
+	}
+}
+
+func (b *Bot) askBroadcastText(chatID int64, audience models.BroadcastAudience) {
+	session := b.getSession(chatID)
+	session.TempData["broadcast_audience"] = audience
+	session.CurrentState = StateAdminBroadcastText
+	b.sendMessage(chatID, fmt.Sprintf("Аудитория: %s.\n\nВведите текст рассылки:", describeAudience(audience)))
+}
+
+// handleBroadcastText принимает текст рассылки и показывает предпросмотр
+func (b *Bot) handleBroadcastText(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	if !b.requirePermission(chatID, PermBroadcast) {
+		return
+	}
+
+	session := b.getSession(chatID)
+	audience, ok := session.TempData["broadcast_audience"].(models.BroadcastAudience)
+	if !ok {
+		session.CurrentState = StateDefault
+		b.sendMessage(chatID, "Аудитория не выбрана. Начните заново: /broadcast")
+		return
+	}
+	if strings.TrimSpace(msg.Text) == "" {
+		b.sendMessage(chatID, "Текст рассылки не может быть пустым:")
+		return
+	}
+
+	recipients, err := b.db.GetBroadcastAudience(audience)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения аудитории рассылки: %v", err)
+		b.sendMessage(chatID, "Произошла ошибка при подсчете получателей.")
+		return
+	}
+
+	session.TempData["broadcast_text"] = msg.Text
+	session.CurrentState = StateDefault
+
+	b.sendMessage(chatID, "Предпросмотр рассылки:")
+	b.sendMessage(chatID, msg.Text)
+	b.sendMessage(chatID,
+		fmt.Sprintf("Аудитория: %s\nПолучателей: %d\n\nОтправить?", describeAudience(audience), len(recipients)),
+		tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Отправить", "bc_send"),
+				tgbotapi.NewInlineKeyboardButtonData("Отмена", "bc_cancel"),
+			),
+		))
+}
+
+// enqueueBroadcast сохраняет рассылку и ставит ее в очередь отправки
+func (b *Bot) enqueueBroadcast(adminID int64, audience models.BroadcastAudience, text string) {
+	// Список получателей пересчитываем на момент отправки
+	recipients, err := b.db.GetBroadcastAudience(audience)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения аудитории рассылки: %v", err)
+		b.sendMessage(adminID, "Произошла ошибка при подготовке рассылки.")
+		return
+	}
+	if len(recipients) == 0 {
+		b.sendMessage(adminID, "Нет получателей для рассылки.")
+		return
+	}
+
+	id, err := b.db.CreateBroadcast(models.Broadcast{
+		CreatedBy: adminID,
+		Text:      text,
+		Audience:  describeAudience(audience),
+		ClaimedAt: clock.Now(),
+	}, recipients)
+	if err != nil {
+		log.Printf("⚠️ Ошибка сохранения рассылки: %v", err)
+		b.sendMessage(adminID, "Не удалось сохранить рассылку.")
+		return
+	}
+
+	// Пока идет предыдущая рассылка, очередь может быть заполнена: ждем места в фоне,
+	// чтобы не останавливать обработку сообщений
+	job := broadcastJob{ID: id, AdminID: adminID, Text: text, Recipients: recipients}
+	go func() { b.broadcasts <- job }()
+	b.sendMessage(adminID, fmt.Sprintf("Рассылка №%d поставлена в очередь (%d получателей). Пришлю отчет по завершении.", id, len(recipients)))
+}
+
+// resumeBroadcasts ставит в очередь рассылки, прерванные перезапуском бота
+func (b *Bot) resumeBroadcasts() {
+	pending, broadcasts, err := b.db.ClaimUnfinishedBroadcasts(b.startedAt)
+	if err != nil {
+		log.Printf("⚠️ Ошибка восстановления рассылок: %v", err)
+		return
+	}
+	for _, broadcast := range broadcasts {
+		log.Printf("🔁 Продолжаем рассылку №%d (осталось %d получателей)", broadcast.ID, len(pending[broadcast.ID]))
+		b.broadcasts <- broadcastJob{
+			ID:         broadcast.ID,
+			AdminID:    broadcast.CreatedBy,
+			Text:       broadcast.Text,
+			Recipients: pending[broadcast.ID],
+		}
+	}
+}
+
+// broadcastWorker отправляет рассылки из очереди по одной, соблюдая лимиты Telegram
+func (b *Bot) broadcastWorker() {
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	for job := range b.broadcasts {
+		log.Printf("📣 Начата рассылка №%d", job.ID)
+		for _, telegramID := range job.Recipients {
+			<-ticker.C
+			status, errText := b.sendBroadcastMessage(telegramID, job.Text)
+			if err := b.db.SetBroadcastRecipientStatus(job.ID, telegramID, status, errText); err != nil {
+				log.Printf("⚠️ %v", err)
+			}
+		}
+
+		result, err := b.db.FinishBroadcast(job.ID)
+		if err != nil {
+			log.Printf("⚠️ %v", err)
+			continue
+		}
+		log.Printf("✅ Рассылка №%d завершена", job.ID)
+		b.sendMessage(job.AdminID, fmt.Sprintf(
+			"Рассылка №%d завершена.\n\nВсего получателей: %d\nДоставлено: %d\nЗаблокировали бота: %d\nОшибки: %d",
+			result.ID, result.Total, result.Delivered, result.Blocked, result.Failed))
+	}
+}
+
+// sendBroadcastMessage отправляет одно сообщение рассылки с учетом retry_after
+func (b *Bot) sendBroadcastMessage(telegramID int64, text string) (string, string) {
+	for attempt := 0; ; attempt++ {
+		_, err := b.api.Send(tgbotapi.NewMessage(telegramID, text))
+		if err == nil {
+			return models.RecipientDelivered, ""
+		}
+
+		var tgErr *tgbotapi.Error
+		if !errors.As(err, &tgErr) {
+			return models.RecipientFailed, err.Error()
+		}
+		switch {
+		case tgErr.Code == 429 && attempt < broadcastMaxRetries:
+			retryAfter := time.Duration(tgErr.RetryAfter) * time.Second
+			if retryAfter <= 0 {
+				retryAfter = time.Second
+			}
+			log.Printf("⏳ Лимит Telegram, ждем %v", retryAfter)
+			time.Sleep(retryAfter)
+		case tgErr.Code == 403:
+			// Пользователь заблокировал бота или удалил аккаунт
+			return models.RecipientBlocked, tgErr.Message
+		default:
+			return models.RecipientFailed, tgErr.Message
+		}
+	}
+}
+
+// describeAudience возвращает описание аудитории для администратора
+func describeAudience(audience models.BroadcastAudience) string {
+	switch audience.Kind {
+	case models.AudienceAll:
+		return "все пользователи"
+	case models.AudienceCustomers:
+		return "все клиенты дома"
+	case models.AudienceEntrance:
+		return fmt.Sprintf("клиенты подъезда %d", audience.Entrance)
+	case models.AudienceStatus:
+		return fmt.Sprintf("клиенты со статусом «%s»", i18n.T(i18n.Default, "status."+audience.Status))
+	case models.AudienceNoOrders:
+		return fmt.Sprintf("клиенты без заказа с %s", audience.SeasonStart.Format("02.01.2006"))
+	}
+	return audience.Kind
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Кнопки карточки заказа несут токен "<сессия>.<шаг>" после разделителя: нажатие
+// принимается, только если кнопка показана в текущей сессии на текущем шаге.
+// Так старые кнопки (из прошлого заказа, до перезапуска бота или с предыдущего шага)
+// не меняют заказ, который клиент оформляет сейчас.
+const callbackTokenSeparator = "|"
+
+// orderCallbackPrefixes — действия диалога заказа, которые требуют токена
+var orderCallbackPrefixes = []string{
+	"entrance_", "windows_", "item_", "count_", "balcony_", "glazing_", "addon_", "addons_done", "quote_send", "notes_", "edit_",
+	"skip_nick", "use_nick", "confirm_order", "cancel_order", "back",
+}
+
+func isOrderCallback(data string) bool {
+	for _, prefix := range orderCallbackPrefixes {
+		if strings.HasPrefix(data, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSessionToken возвращает случайный идентификатор сессии диалога
+func newSessionToken() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("⚠️ Ошибка генерации токена сессии: %v", err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// nextStepToken начинает новый шаг карточки: кнопки прошлых шагов перестают действовать
+func (s *UserSession) nextStepToken() string {
+	if s.Token == "" {
+		s.Token = newSessionToken()
+	}
+	s.Step++
+	return s.Token + "." + strconv.Itoa(s.Step)
+}
+
+// stampKeyboard добавляет токен шага к кнопкам диалога заказа
+func stampKeyboard(markup tgbotapi.InlineKeyboardMarkup, token string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, len(markup.InlineKeyboard))
+	for i, row := range markup.InlineKeyboard {
+		rows[i] = make([]tgbotapi.InlineKeyboardButton, len(row))
+		for j, button := range row {
+			if button.CallbackData != nil && isOrderCallback(*button.CallbackData) {
+				data := *button.CallbackData + callbackTokenSeparator + token
+				button.CallbackData = &data
+			}
+			rows[i][j] = button
+		}
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// splitCallbackData отделяет действие от токена шага
+func splitCallbackData(data string) (action, token string) {
+	action, token, _ = strings.Cut(data, callbackTokenSeparator)
+	return action, token
+}
+
+// checkOrderCallback проверяет токен и значение кнопки диалога заказа.
+// Возвращает текст всплывающего уведомления, если нажатие нужно отклонить.
+func (b *Bot) checkOrderCallback(chatID int64, messageID int, action, token string) string {
+	session, ok := userSessions[chatID]
+	if !ok || token == "" || token != session.Token+"."+strconv.Itoa(session.Step) {
+		// С устаревшего сообщения кнопки убираем, чтобы их не нажимали снова
+		if !ok || messageID != session.CardMessageID {
+			b.removeInlineKeyboard(chatID, messageID)
+		}
+		return b.t(chatID, "callback.stale")
+	}
+	if !validOrderCallback(action) {
+		log.Printf("⚠️ Некорректные данные кнопки от %d: %s", chatID, action)
+		return b.t(chatID, "callback.invalid")
+	}
+	return ""
+}
+
+// validOrderCallback проверяет, что значение кнопки допустимо
+func validOrderCallback(action string) bool {
+	inRange := func(value string, min, max int) bool {
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= min && n <= max && strconv.Itoa(n) == value
+	}
+	isSash := func(value string) bool {
+		return value == "3" || value == "4" || value == "5" || value == "6_7"
+	}
+
+	switch {
+	case strings.HasPrefix(action, "entrance_"):
+		return inRange(strings.TrimPrefix(action, "entrance_"), 1, maxEntrance)
+	case strings.HasPrefix(action, "count_step_"):
+		return inRange(strings.TrimPrefix(action, "count_step_"), 0, maxWindowCount())
+	case strings.HasPrefix(action, "count_"):
+		return inRange(strings.TrimPrefix(action, "count_"), 0, maxWindowCount())
+	case strings.HasPrefix(action, "item_"):
+		item, ok := findItem(strings.TrimPrefix(action, "item_"))
+		return ok && item.Active
+	case strings.HasPrefix(action, "balcony_sash_"):
+		return isSash(strings.TrimPrefix(action, "balcony_sash_"))
+	case action == "balcony_standard" || action == "balcony_floor":
+		return true
+	case strings.HasPrefix(action, "glazing_"):
+		glazing := strings.TrimPrefix(action, "glazing_")
+		return glazing == models.GlazingCold || glazing == models.GlazingWarm || glazing == "unknown"
+	case strings.HasPrefix(action, "balcony_"):
+		return inRange(strings.TrimPrefix(action, "balcony_"), 0, maxBalconyCount)
+	case strings.HasPrefix(action, "addon_"):
+		addon, ok := findAddon(strings.TrimPrefix(action, "addon_"))
+		return ok && addon.Active
+	case strings.HasPrefix(action, "notes_time_"):
+		return isPreferredTime(strings.TrimPrefix(action, "notes_time_"))
+	}
+
+	switch action {
+	case "windows_same", "windows_different", "windows_custom", "quote_send", "addons_done", "notes_presence", "notes_intercom", "notes_comment_clear", "notes_done", "skip_nick", "use_nick", "confirm_order", "cancel_order", "back",
+		"edit_address", "edit_windows", "edit_balcony", "edit_addons", "edit_nick", "edit_phone", "edit_notes":
+		return true
+	}
+	return false
+}
+
+// answerCallback отвечает на нажатие кнопки; непустой текст показывается всплывающим уведомлением
+func (b *Bot) answerCallback(callbackID, text string) {
+	if _, err := b.api.Request(tgbotapi.NewCallback(callbackID, text)); err != nil {
+		log.Printf("⚠️ Ошибка ответа на callback: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package bot
+
+import "testing"
+
+func TestValidOrderCallback(t *testing.T) {
+	useTestCatalog(t)
+	t.Setenv("MAX_WINDOW_COUNT", "")
+
+	tests := []struct {
+		action string
+		want   bool
+	}{
+		{"entrance_1", true},
+		{"entrance_6", true},
+		{"entrance_0", false},
+		{"entrance_7", false},
+		{"entrance_01", false},
+		{"entrance_+1", false},
+		{"entrance_", false},
+		{"count_0", true},
+		{"count_30", true},
+		{"count_31", false},
+		{"count_-1", false},
+		{"count_step_12", true},
+		{"count_step_31", false},
+		{"item_window_3", true},
+		{"item_door", true},
+		{"item_window_2", false},
+		{"item_unknown", false},
+		{"balcony_0", true},
+		{"balcony_3", true},
+		{"balcony_4", false},
+		{"balcony_standard", true},
+		{"balcony_floor", true},
+		{"balcony_panoramic", false},
+		{"balcony_sash_6_7", true},
+		{"balcony_sash_2", false},
+		{"glazing_cold", true},
+		{"glazing_warm", true},
+		{"glazing_unknown", true},
+		{"glazing_hot", false},
+		{"addon_frames", true},
+		{"addon_blinds", false},
+		{"addon_unknown", false},
+		{"notes_time_morning", true},
+		{"notes_time_night", false},
+		{"notes_done", true},
+		{"confirm_order", true},
+		{"edit_notes", true},
+		{"edit_price", false},
+		{"back", true},
+		{"drop_table", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			if got := validOrderCallback(tt.action); got != tt.want {
+				t.Errorf("validOrderCallback(%q) = %v, want %v", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+// Предел количества окон задается переменной окружения; значение меньше кнопок быстрого выбора
+// или нечисловое игнорируется
+func TestValidOrderCallbackMaxWindowCount(t *testing.T) {
+	tests := []struct {
+		env    string
+		action string
+		want   bool
+	}{
+		{"50", "count_50", true},
+		{"50", "count_51", false},
+		{"3", "count_30", true},
+		{"3", "count_31", false},
+		{"много", "count_30", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env+"/"+tt.action, func(t *testing.T) {
+			t.Setenv("MAX_WINDOW_COUNT", tt.env)
+			if got := validOrderCallback(tt.action); got != tt.want {
+				t.Errorf("MAX_WINDOW_COUNT=%s: validOrderCallback(%q) = %v, want %v", tt.env, tt.action, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Диалог заказа ведется в одном сообщении — карточке заказа. Шаги с кнопками меняют ее
+// на месте; после ввода текста карточка уходит вверх по чату, поэтому у нее убираются
+// кнопки, а следующий шаг показывается в новой карточке.
+
+// startOrderSession начинает новый заказ. cardMessageID — сообщение, которое станет
+// карточкой заказа (например, приветствие с кнопкой «Новый заказ»), или 0.
+func (b *Bot) startOrderSession(chatID int64, order models.Order, cardMessageID int) *UserSession {
+	if old, ok := userSessions[chatID]; ok && old.CardMessageID != cardMessageID {
+		b.supersedeCard(chatID)
+	}
+
+	session := &UserSession{
+		Order:         order,
+		TempData:      make(map[string]interface{}),
+		CardMessageID: cardMessageID,
+		Token:         newSessionToken(),
+	}
+	userSessions[chatID] = session
+	return session
+}
+
+// showStep показывает шаг диалога в карточке заказа: сверху уже выбранные данные, ниже вопрос
+func (b *Bot) showStep(chatID int64, prompt string, markup ...tgbotapi.InlineKeyboardMarkup) {
+	text := prompt
+	if summary := b.orderSummary(chatID); summary != "" {
+		text = summary + "\n\n" + prompt
+	}
+	b.showCard(chatID, text, markup...)
+}
+
+// showCard заменяет текст и кнопки карточки заказа. Если карточки нет или изменить ее
+// не удалось, отправляется новая. Кнопки получают токен нового шага.
+func (b *Bot) showCard(chatID int64, text string, markup ...tgbotapi.InlineKeyboardMarkup) {
+	session := b.getSession(chatID)
+	token := session.nextStepToken()
+	for i := range markup {
+		markup[i] = stampKeyboard(markup[i], token)
+	}
+	if session.CardMessageID != 0 {
+		edit := tgbotapi.NewEditMessageText(chatID, session.CardMessageID, text)
+		if len(markup) > 0 {
+			edit.ReplyMarkup = &markup[0]
+		}
+		_, err := b.api.Request(edit)
+		if err == nil || isMessageNotModified(err) {
+			return
+		}
+		log.Printf("⚠️ Ошибка изменения карточки заказа: %v", err)
+		b.supersedeCard(chatID)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	if len(markup) > 0 {
+		msg.ReplyMarkup = markup[0]
+	}
+	sent, err := b.api.Send(msg)
+	if err != nil {
+		log.Printf("⚠️ Ошибка отправки сообщения: %v", err)
+		return
+	}
+	session.CardMessageID = sent.MessageID
+}
+
+// supersedeCard убирает кнопки с текущей карточки заказа; следующий шаг будет показан в новой
+func (b *Bot) supersedeCard(chatID int64) {
+	session, ok := userSessions[chatID]
+	if !ok || session.CardMessageID == 0 {
+		return
+	}
+
+	b.removeInlineKeyboard(chatID, session.CardMessageID)
+	session.CardMessageID = 0
+}
+
+// removeInlineKeyboard убирает кнопки у сообщения, чтобы их нельзя было нажать повторно
+func (b *Bot) removeInlineKeyboard(chatID int64, messageID int) {
+	edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	})
+	if _, err := b.api.Request(edit); err != nil && !isMessageNotModified(err) {
+		log.Printf("⚠️ Ошибка удаления кнопок: %v", err)
+	}
+}
+
+// isMessageNotModified — Telegram отвечает ошибкой, если новое содержимое совпадает со старым
+func isMessageNotModified(err error) bool {
+	return strings.Contains(err.Error(), "message is not modified")
+}
+
+// orderSummary перечисляет уже выбранные данные заказа для карточки.
+// Данные шага, который клиент сейчас заполняет, не показываются, пока он его не завершит.
+func (b *Bot) orderSummary(chatID int64) string {
+	session := b.getSession(chatID)
+	order := session.Order
+	lang := b.lang(chatID)
+
+	var lines []string
+	if order.Source == models.OrderSourcePhone && order.CustomerName != "" {
+		lines = append(lines, i18n.T(lang, "card.customer", order.CustomerName))
+	}
+	if order.Entrance != 0 {
+		lines = append(lines, i18n.T(lang, "card.entrance", order.Entrance))
+	}
+	if order.Floor != 0 {
+		lines = append(lines, i18n.T(lang, "card.floor", order.Floor))
+	}
+	if order.Apartment != "" {
+		lines = append(lines, i18n.T(lang, "card.apartment", order.Apartment))
+	}
+
+	if !isWindowsState(session.CurrentState) {
+		for _, item := range order.Items {
+			lines = append(lines, i18n.T(lang, "card.item", itemTitleByCode(lang, item.ItemType), item.Quantity))
+		}
+	}
+	loggias := order.Loggias
+	switch session.CurrentState {
+	case StateBalconyNeeded:
+		loggias = nil
+	case StateBalconyType, StateBalconySash, StateBalconyGlazing:
+		// Последняя лоджия еще заполняется
+		if len(loggias) > 0 {
+			loggias = loggias[:len(loggias)-1]
+		}
+	}
+	for i, loggia := range loggias {
+		lines = append(lines, i18n.T(lang, "card.loggia", i+1, loggiaTitle(lang, loggia)))
+	}
+
+	if len(order.Addons) > 0 && session.CurrentState != StateAddons {
+		titles := make([]string, len(order.Addons))
+		for i, addon := range order.Addons {
+			titles[i] = addonTitleByCode(lang, addon.AddonType)
+		}
+		lines = append(lines, i18n.T(lang, "card.addons", strings.Join(titles, ", ")))
+	}
+
+	if order.TelegramNick != "" {
+		lines = append(lines, i18n.T(lang, "card.nick", order.TelegramNick))
+	}
+	if order.CustomerPhone != "" {
+		lines = append(lines, i18n.T(lang, "card.phone", order.CustomerPhone))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return i18n.T(lang, "card.title") + "\n" + strings.Join(lines, "\n")
+}
+
+func isWindowsState(state string) bool {
+	switch state {
+	case StateWindowsSameOrDifferent, StateWindowsSameType, StateWindowsSameCount,
+		StateWindowsDifferent:
+		return true
+	}
+	return false
+}
+
+// loggiaTitle описывает лоджию: тип окон, створки и остекление, если оно известно
+func loggiaTitle(lang string, loggia models.Loggia) string {
+	loggiaType := i18n.T(lang, "loggia.standard")
+	if loggia.Type == models.LoggiaFloor {
+		loggiaType = i18n.T(lang, "loggia.floor")
+	}
+	title := i18n.T(lang, "loggia.title", loggiaType, sashTitle(loggia.Sash))
+	if loggia.Glazing != "" {
+		title += ", " + i18n.T(lang, "loggia.glazing_"+loggia.Glazing)
+	}
+	return title
+}
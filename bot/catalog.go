@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/storage"
+)
+
+// Каталог изделий (типы окон и другие позиции заказа) хранится в таблице item_types,
+// каталог дополнительных услуг — в addon_types. Оба загружаются при запуске: новая позиция
+// появляется в диалоге, расчете стоимости и выгрузках после перезапуска бота без изменений кода.
+var (
+	itemCatalog   []models.ItemType
+	addonCatalog  []models.AddonType
+	itemCatalogMu sync.RWMutex
+)
+
+// LoadItemCatalog читает из БД каталоги изделий и дополнительных услуг
+func LoadItemCatalog(db *storage.Postgres) error {
+	items, err := db.GetItemTypes()
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return errors.New("каталог изделий пуст: заполните таблицу item_types")
+	}
+	// Без дополнительных услуг шаг их выбора просто пропускается
+	addons, err := db.GetAddonTypes()
+	if err != nil {
+		return err
+	}
+
+	itemCatalogMu.Lock()
+	itemCatalog = items
+	addonCatalog = addons
+	itemCatalogMu.Unlock()
+	return nil
+}
+
+// catalogItems возвращает все позиции каталога, включая выведенные из продажи: они
+// нужны для старых заказов и выгрузок
+func catalogItems() []models.ItemType {
+	itemCatalogMu.RLock()
+	defer itemCatalogMu.RUnlock()
+	return itemCatalog
+}
+
+// activeItems возвращает позиции, которые предлагаются клиентам
+func activeItems() []models.ItemType {
+	var items []models.ItemType
+	for _, item := range catalogItems() {
+		if item.Active {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// findItem возвращает позицию каталога по коду
+func findItem(code string) (models.ItemType, bool) {
+	for _, item := range catalogItems() {
+		if item.Code == code {
+			return item, true
+		}
+	}
+	return models.ItemType{}, false
+}
+
+// itemTitle возвращает название позиции на языке lang (или на языке по умолчанию)
+func itemTitle(lang string, item models.ItemType) string {
+	return catalogTitle(lang, item.Titles, item.Code)
+}
+
+// catalogTitle выбирает название на языке lang, затем на языке по умолчанию, затем код
+func catalogTitle(lang string, titles map[string]string, code string) string {
+	if title := titles[lang]; title != "" {
+		return title
+	}
+	if title := titles[i18n.Default]; title != "" {
+		return title
+	}
+	return code
+}
+
+// itemTitleByCode возвращает название позиции заказа; код, которого нет в каталоге,
+// показывается как есть
+func itemTitleByCode(lang, code string) string {
+	if item, ok := findItem(code); ok {
+		return itemTitle(lang, item)
+	}
+	return code
+}
+
+// sashPrice возвращает цену окна с указанным количеством створок; по ней считаются лоджии
+func sashPrice(sash string) int {
+	// У изделий, которые не являются окнами, створки не указаны
+	if sash == "" {
+		return 0
+	}
+	for _, item := range catalogItems() {
+		if item.Sash == sash {
+			return item.Price
+		}
+	}
+	return 0
+}
+
+// catalogAddons возвращает все дополнительные услуги, включая выведенные из продажи
+func catalogAddons() []models.AddonType {
+	itemCatalogMu.RLock()
+	defer itemCatalogMu.RUnlock()
+	return addonCatalog
+}
+
+// findAddon возвращает дополнительную услугу по коду
+func findAddon(code string) (models.AddonType, bool) {
+	for _, addon := range catalogAddons() {
+		if addon.Code == code {
+			return addon, true
+		}
+	}
+	return models.AddonType{}, false
+}
+
+// addonTitle возвращает название дополнительной услуги на языке lang
+func addonTitle(lang string, addon models.AddonType) string {
+	return catalogTitle(lang, addon.Titles, addon.Code)
+}
+
+// addonTitleByCode возвращает название услуги заказа; код, которого нет в каталоге,
+// показывается как есть
+func addonTitleByCode(lang, code string) string {
+	if addon, ok := findAddon(code); ok {
+		return addonTitle(lang, addon)
+	}
+	return code
+}
+
+// addonQuantity считает, сколько единиц услуги приходится на заказ: по окнам и/или
+// лоджиям или одну на заказ. Ноль — услуга к заказу не применима.
+func addonQuantity(addon models.AddonType, order models.Order) int {
+	count := 0
+	if addon.AppliesTo == models.AddonForWindows || addon.AppliesTo == models.AddonForAll {
+		count += order.ItemCount()
+	}
+	if addon.AppliesTo == models.AddonForLoggias || addon.AppliesTo == models.AddonForAll {
+		count += len(order.Loggias)
+	}
+	if addon.Unit == models.AddonUnitOrder && count > 0 {
+		return 1
+	}
+	return count
+}
+
+// availableAddons возвращает услуги, которые можно предложить к заказу
+func availableAddons(order models.Order) []models.AddonType {
+	var addons []models.AddonType
+	for _, addon := range catalogAddons() {
+		if addon.Active && addonQuantity(addon, order) > 0 {
+			addons = append(addons, addon)
+		}
+	}
+	return addons
+}
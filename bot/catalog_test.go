@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// useTestCatalog подменяет каталоги изделий и услуг на время теста: цены как в миграциях,
+// плюс выведенная из продажи позиция и изделие без створок
+func useTestCatalog(t *testing.T) {
+	t.Helper()
+
+	itemCatalogMu.Lock()
+	savedItems, savedAddons := itemCatalog, addonCatalog
+	itemCatalog = []models.ItemType{
+		{Code: "window_3", Titles: map[string]string{"ru": "3-створчатые", "en": "3-sash"}, Sash: "3", Price: 1000, Position: 10, Active: true},
+		{Code: "window_4", Titles: map[string]string{"ru": "4-створчатые", "en": "4-sash"}, Sash: "4", Price: 1500, Position: 20, Active: true},
+		{Code: "window_5", Titles: map[string]string{"ru": "5-створчатые", "en": "5-sash"}, Sash: "5", Price: 2000, Position: 30, Active: true},
+		{Code: "window_6_7", Titles: map[string]string{"ru": "6-7-створчатые", "en": "6-7-sash"}, Sash: "6_7", Price: 2500, Position: 40, Active: true},
+		{Code: "door", Titles: map[string]string{"ru": "Балконная дверь"}, Price: 700, Position: 50, Active: true},
+		{Code: "window_2", Titles: map[string]string{"ru": "2-створчатые"}, Sash: "2", Price: 800, Position: 60, Active: false},
+	}
+	addonCatalog = []models.AddonType{
+		{Code: "frames", Titles: map[string]string{"ru": "Мытье рам"}, Unit: models.AddonUnitPiece, AppliesTo: models.AddonForAll, Price: 300, Position: 10, Active: true},
+		{Code: "sills", Titles: map[string]string{"ru": "Мытье подоконников"}, Unit: models.AddonUnitPiece, AppliesTo: models.AddonForWindows, Price: 200, Position: 20, Active: true},
+		{Code: "trip", Titles: map[string]string{"ru": "Выезд"}, Unit: models.AddonUnitOrder, AppliesTo: models.AddonForAll, Price: 500, Position: 30, Active: true},
+		{Code: "blinds", Titles: map[string]string{"ru": "Чистка жалюзи"}, Unit: models.AddonUnitPiece, AppliesTo: models.AddonForWindows, Price: 400, Position: 40, Active: false},
+	}
+	itemCatalogMu.Unlock()
+
+	t.Cleanup(func() {
+		itemCatalogMu.Lock()
+		itemCatalog, addonCatalog = savedItems, savedAddons
+		itemCatalogMu.Unlock()
+	})
+}
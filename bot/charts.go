@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/eugenepelipets/window-wash-bot/charts"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// sendStatsCharts рисует графики по статистике и отправляет их одним альбомом каждому получателю
+func (b *Bot) sendStatsCharts(stats models.OrderStats, chatIDs ...int64) {
+	daily, err := b.db.GetDailyOrderCounts(stats.From, stats.To)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения заказов по дням: %v", err)
+		return
+	}
+
+	images, err := buildStatsCharts(stats, daily)
+	if err != nil {
+		log.Printf("⚠️ Ошибка построения графиков: %v", err)
+		for _, chatID := range chatIDs {
+			b.sendMessage(chatID, "Не удалось построить графики.")
+		}
+		return
+	}
+
+	media := make([]interface{}, 0, len(images))
+	for _, img := range images {
+		media = append(media, tgbotapi.NewInputMediaPhoto(img))
+	}
+	for _, chatID := range chatIDs {
+		if _, err := b.api.SendMediaGroup(tgbotapi.NewMediaGroup(chatID, media)); err != nil {
+			log.Printf("⚠️ Ошибка отправки графиков: %v", err)
+		}
+	}
+}
+
+// buildStatsCharts строит PNG-графики: заказы по дням, выручка по подъездам,
+// распределение по типам створок и воронка заказов
+func buildStatsCharts(stats models.OrderStats, daily []models.DailyCount) ([]tgbotapi.FileBytes, error) {
+	var images []tgbotapi.FileBytes
+
+	// Заказы по дням
+	points := make([]charts.Bar, 0, len(daily))
+	for _, dc := range daily {
+		points = append(points, charts.Bar{Label: dc.Day.Format("02.01"), Value: dc.Orders})
+	}
+	img, err := charts.LineChart("Заказы по дням", points)
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, tgbotapi.FileBytes{Name: "orders_per_day.png", Bytes: img})
+
+	// Выручка по подъездам
+	entrances := make([]int, 0, len(stats.RevenueByEntrance))
+	for entrance := range stats.RevenueByEntrance {
+		entrances = append(entrances, entrance)
+	}
+	sort.Ints(entrances)
+	bars := make([]charts.Bar, 0, len(entrances))
+	for _, entrance := range entrances {
+		bars = append(bars, charts.Bar{
+			Label: fmt.Sprintf("Подъезд %d", entrance),
+			Value: stats.RevenueByEntrance[entrance],
+		})
+	}
+	img, err = charts.BarChart("Выручка по подъездам, руб.", bars)
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, tgbotapi.FileBytes{Name: "revenue_per_entrance.png", Bytes: img})
+
+	// Распределение по позициям каталога
+	var itemBars []charts.Bar
+	for _, item := range catalogItems() {
+		itemBars = append(itemBars, charts.Bar{Label: itemTitle(i18n.Default, item), Value: stats.ItemCounts[item.Code]})
+	}
+	img, err = charts.BarChart("Окна по типам", itemBars)
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, tgbotapi.FileBytes{Name: "sash_types.png", Bytes: img})
+
+	// Воронка заказов
+	img, err = charts.HorizontalBarChart("Воронка заказов", []charts.Bar{
+		{Label: "Новые пользователи", Value: stats.NewUsers},
+		{Label: "Оформили заказ", Value: stats.Customers},
+		{Label: "Всего заказов", Value: stats.TotalOrders},
+		{Label: "Подтверждено", Value: stats.ByStatus["confirmed"]},
+	})
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, tgbotapi.FileBytes{Name: "order_funnel.png", Bytes: img})
+
+	return images, nil
+}
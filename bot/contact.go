@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"log"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// StateContactPhone — клиент должен поделиться номером телефона или ввести его вручную
+const StateContactPhone = "contact_phone"
+
+// askContactPhone переходит к шагу телефона. В заказах по телефону номер уже введен
+// администратором, и шаг пропускается. Следующий шаг — пожелания к заказу.
+func (b *Bot) askContactPhone(chatID int64) {
+	session := b.getSession(chatID)
+	if session.Order.Source == models.OrderSourcePhone {
+		b.askNotes(chatID)
+		return
+	}
+
+	b.updateState(chatID, StateContactPhone)
+	b.sendContactRequest(chatID)
+}
+
+// sendContactRequest показывает клавиатуру с кнопкой отправки контакта
+// и сохраненным ранее номером, если он есть
+func (b *Bot) sendContactRequest(chatID int64) {
+	savedPhone := ""
+	user, err := b.db.GetUser(chatID)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	} else if user != nil {
+		savedPhone = user.Phone
+	}
+
+	b.getSession(chatID).TempData["saved_phone"] = savedPhone
+
+	// Клавиатуру с кнопкой контакта нельзя прикрепить к карточке заказа: карточка
+	// остается со сводкой без кнопок, а запрос номера отправляется отдельным сообщением
+	if summary := b.orderSummary(chatID); summary != "" {
+		b.showCard(chatID, summary)
+	}
+	b.supersedeCard(chatID)
+
+	lang := b.lang(chatID)
+	text := i18n.T(lang, "order.contact_request")
+	if savedPhone != "" {
+		text = i18n.T(lang, "order.contact_saved", savedPhone)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = createContactKeyboard(lang, savedPhone)
+	if _, err := b.api.Send(msg); err != nil {
+		log.Printf("⚠️ Ошибка отправки сообщения: %v", err)
+	}
+}
+
+// handleContactPhone принимает контакт, сохраненный номер или номер, введенный вручную
+func (b *Bot) handleContactPhone(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	text := strings.TrimSpace(msg.Text)
+	lang := b.lang(chatID)
+	savedPhone, _ := b.getSession(chatID).TempData["saved_phone"].(string)
+
+	var phone string
+	var ok bool
+	switch {
+	case msg.Contact != nil && (msg.From == nil || msg.Contact.UserID != msg.From.ID):
+		// Принимаем только собственный контакт, а не пересланную карточку другого человека
+		b.sendMessage(chatID, i18n.T(lang, "order.contact_foreign", i18n.T(lang, "btn.share_contact")))
+		return
+	case msg.Contact != nil:
+		// В контакте Telegram номер всегда с кодом страны, но может быть без "+"
+		number := msg.Contact.PhoneNumber
+		if !strings.HasPrefix(number, "+") {
+			number = "+" + number
+		}
+		phone, ok = NormalizePhone(number)
+	case text == i18n.T(lang, "btn.reply_back"):
+		b.removeReplyKeyboard(chatID, i18n.T(lang, "order.back_to_previous"))
+		b.handleBack(chatID)
+		return
+	case savedPhone != "" && text == i18n.T(lang, "btn.keep_phone", savedPhone):
+		phone, ok = savedPhone, true
+	default:
+		phone, ok = NormalizePhone(text)
+	}
+	if !ok {
+		b.sendMessage(chatID, i18n.T(lang, "order.contact_invalid", i18n.T(lang, "btn.share_contact")))
+		return
+	}
+
+	if err := b.db.SetUserPhone(chatID, phone); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+
+	session := b.getSession(chatID)
+	session.Order.CustomerPhone = phone
+	b.removeReplyKeyboard(chatID, i18n.T(lang, "order.contact_phone", phone))
+	b.continueOrder(chatID, func() { b.askNotes(chatID) })
+}
+
+// removeReplyKeyboard отправляет сообщение и убирает клавиатуру шага телефона
+func (b *Bot) removeReplyKeyboard(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+	if _, err := b.api.Send(msg); err != nil {
+		log.Printf("⚠️ Ошибка отправки сообщения: %v", err)
+	}
+}
+
+func createContactKeyboard(lang, savedPhone string) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	if savedPhone != "" {
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, "btn.keep_phone", savedPhone))))
+	}
+	rows = append(rows,
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonContact(i18n.T(lang, "btn.share_contact"))),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, "btn.reply_back"))),
+	)
+
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.ResizeKeyboard = true
+	return keyboard
+}
@@ -3,94 +3,143 @@ package bot
 import (
 	"bytes"
 	"encoding/csv"
+	"fmt"
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
 	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/storage"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"log"
-	"os"
 	"strconv"
 	"strings"
-	"time"
 )
 
-var moscowLoc *time.Location
+// handleExport обрабатывает команду экспорта.
+// Без аргументов показывает мастер выбора фильтров, иначе разбирает аргументы вида
+// from=2026-04-01 to=2026-04-30 entrance=3 status=confirmed format=xlsx
+func (b *Bot) handleExport(msg *tgbotapi.Message) {
+	// Проверяем права доступа
+	if !b.requirePermission(msg.Chat.ID, PermExport) {
+		return
+	}
 
-func init() {
-	// Инициализация при загрузке пакета
-	initLocation()
-}
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		b.sendExportWizard(msg.Chat.ID)
+		return
+	}
 
-func initLocation() {
-	var err error
-	moscowLoc, err = time.LoadLocation("Europe/Istanbul")
+	lang := b.lang(msg.Chat.ID)
+	req, err := parseExportArgs(lang, args, clock.Now())
 	if err != nil {
-		log.Printf("⚠️ Failed to load Istanbul location: %v (falling back to UTC)", err)
-		moscowLoc = time.UTC
+		b.sendMessage(msg.Chat.ID, i18n.T(lang, "export.error", err)+"\n\n"+exportUsage(lang))
+		return
+	}
+
+	if req.Lang == "" {
+		req.Lang = lang
 	}
+	b.sendExport(msg.Chat.ID, req)
 }
 
-// handleExport обрабатывает команду экспорта
-func (b *Bot) handleExport(msg *tgbotapi.Message) {
-	// Проверяем, является ли пользователь администратором
-	if !b.isAdmin(msg.Chat.ID) {
-		b.sendMessage(msg.Chat.ID, "У вас нет прав для выполнения этой команды.")
+// sendExport формирует файл выгрузки и отправляет его администратору
+func (b *Bot) sendExport(chatID int64, req exportRequest) {
+	file, caption, err := b.buildExport(req)
+	if err != nil {
+		log.Printf("⚠️ Ошибка подготовки отчета: %v", err)
+		b.sendMessage(chatID, "Произошла ошибка при подготовке отчета.")
 		return
 	}
 
-	// Определяем тип экспорта (по умолчанию - только актуальные)
-	onlyCurrent := true
-	if strings.Contains(msg.Text, "все") || strings.Contains(msg.Text, "all") {
-		onlyCurrent = false
+	// Отправляем файл
+	msgConfig := tgbotapi.NewDocument(chatID, file)
+	msgConfig.Caption = caption
+
+	if _, err := b.api.Send(msgConfig); err != nil {
+		log.Printf("⚠️ Ошибка отправки файла: %v", err)
+		b.sendMessage(chatID, "Не удалось отправить отчет.")
 	}
+}
 
+// buildExport получает заказы по фильтрам и формирует файл выгрузки с подписью
+func (b *Bot) buildExport(req exportRequest) (tgbotapi.FileBytes, string, error) {
 	// Получаем данные из БД
-	orders, err := b.db.GetOrdersForExport(onlyCurrent)
+	orders, err := b.db.GetOrdersForExport(req.Filter)
 	if err != nil {
-		log.Printf("⚠️ Ошибка получения данных для экспорта: %v", err)
-		b.sendMessage(msg.Chat.ID, "Произошла ошибка при подготовке отчета.")
-		return
+		return tgbotapi.FileBytes{}, "", fmt.Errorf("ошибка получения данных для экспорта: %v", err)
 	}
 
-	// Создаем CSV
-	csvData, err := b.createCSV(orders, onlyCurrent)
+	var data []byte
+	switch req.Format {
+	case exportFormatXLSX:
+		data, err = b.createXLSX(orders)
+	case exportFormatJSON, exportFormatNDJSON:
+		data, err = createJSON(b.db, orders, req.Filter, req.Format == exportFormatNDJSON)
+	default:
+		data, err = b.createCSV(orders, req.Lang)
+	}
 	if err != nil {
-		log.Printf("⚠️ Ошибка создания CSV: %v", err)
-		b.sendMessage(msg.Chat.ID, "Произошла ошибка при создании отчета.")
-		return
+		return tgbotapi.FileBytes{}, "", fmt.Errorf("ошибка создания отчета: %v", err)
 	}
 
 	// Формируем название файла
-	fileName := "orders_current_" + time.Now().Format("2006-01-02") + ".csv"
-	if !onlyCurrent {
-		fileName = "orders_all_" + time.Now().Format("2006-01-02") + ".csv"
+	fileName := "orders_current_" + clock.Now().Format("2006-01-02") + "." + req.Format
+	if !req.Filter.OnlyCurrent {
+		fileName = "orders_all_" + clock.Now().Format("2006-01-02") + "." + req.Format
 	}
 
-	// Отправляем файл
 	file := tgbotapi.FileBytes{
 		Name:  fileName,
-		Bytes: csvData,
+		Bytes: data,
 	}
-	msgConfig := tgbotapi.NewDocument(msg.Chat.ID, file)
-	msgConfig.Caption = "Отчет по заказам (" + b.getExportTypeDescription(onlyCurrent) + ")"
+	return file, i18n.T(req.Lang, "export.caption", describeExportFilter(req.Lang, req.Filter)), nil
+}
 
-	if _, err := b.api.Send(msgConfig); err != nil {
-		log.Printf("⚠️ Ошибка отправки файла: %v", err)
-		b.sendMessage(msg.Chat.ID, "Не удалось отправить отчет.")
+// ExportOrders формирует файл выгрузки по аргументам в формате /export (для командной строки)
+func ExportOrders(db *storage.Postgres, args string) (fileName string, data []byte, err error) {
+	req, err := parseExportArgs(i18n.Default, args, clock.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	b := &Bot{db: db}
+	file, _, err := b.buildExport(req)
+	if err != nil {
+		return "", nil, err
 	}
+	return file.Name, file.Bytes, nil
 }
 
-// createCSV создает CSV файл из данных заказов
-func (b *Bot) createCSV(orders []models.Order, onlyCurrent bool) ([]byte, error) {
+// Ключи каталога для заголовков CSV в порядке колонок; между ними — по колонке
+// на каждую позицию каталога изделий
+var (
+	csvLeadColumns = []string{
+		"csv.id", "csv.created_at", "csv.entrance", "csv.floor", "csv.apartment", "csv.windows_same",
+	}
+	csvTailColumns = []string{
+		"csv.balcony_count", "csv.loggias", "csv.addons", "csv.description", "csv.comment", "csv.intercom_code",
+		"csv.presence_required", "csv.preferred_time", "csv.telegram_nick", "csv.price", "csv.status",
+		"csv.is_current", "csv.user_id", "csv.username", "csv.first_name", "csv.last_name",
+		"csv.source", "csv.customer_name", "csv.customer_phone",
+	}
+)
+
+// createCSV создает CSV файл из данных заказов с заголовками на языке lang
+func (b *Bot) createCSV(orders []models.Order, lang string) ([]byte, error) {
 	var buf bytes.Buffer
 	writer := csv.NewWriter(&buf)
 	writer.Comma = ';'
 
 	// Записываем заголовки
-	headers := []string{
-		"ID", "Дата создания", "Подъезд", "Этаж", "Квартира",
-		"Одинаковые створки", "3-створчатые", "4-створчатые",
-		"5-створчатые", "6-7-створчатые", "Лоджии", "Тип лоджии",
-		"Створки лоджии", "Телеграм ник", "Стоимость", "Статус",
-		"Актуальный", "ID пользователя", "Username", "Имя", "Фамилия",
+	items := catalogItems()
+	var headers []string
+	for _, key := range csvLeadColumns {
+		headers = append(headers, i18n.T(lang, key))
+	}
+	for _, item := range items {
+		headers = append(headers, itemTitle(lang, item))
+	}
+	for _, key := range csvTailColumns {
+		headers = append(headers, i18n.T(lang, key))
 	}
 	if err := writer.Write(headers); err != nil {
 		return nil, err
@@ -100,18 +149,24 @@ func (b *Bot) createCSV(orders []models.Order, onlyCurrent bool) ([]byte, error)
 	for _, order := range orders {
 		record := []string{
 			strconv.FormatInt(order.ID, 10),
-			order.CreatedAt.In(moscowLoc).Format("2006-01-02 15:04:05"),
+			order.CreatedAt.In(clock.Location()).Format("2006-01-02 15:04:05"),
 			strconv.Itoa(order.Entrance),
 			strconv.Itoa(order.Floor),
 			order.Apartment,
 			strconv.FormatBool(order.WindowsSame),
-			strconv.Itoa(order.Window3Count),
-			strconv.Itoa(order.Window4Count),
-			strconv.Itoa(order.Window5Count),
-			strconv.Itoa(order.Window6_7Count),
-			strconv.Itoa(order.BalconyCount),
-			order.BalconyType,
-			order.BalconySash,
+		}
+		for _, item := range items {
+			record = append(record, strconv.Itoa(order.Quantity(item.Code)))
+		}
+		record = append(record,
+			strconv.Itoa(len(order.Loggias)),
+			loggiaCodes(order.Loggias),
+			addonCodes(order.Addons),
+			order.Description,
+			order.Comment,
+			order.IntercomCode,
+			strconv.FormatBool(order.Presence),
+			order.PreferredTime,
 			order.TelegramNick,
 			strconv.Itoa(order.Price),
 			order.Status,
@@ -120,7 +175,10 @@ func (b *Bot) createCSV(orders []models.Order, onlyCurrent bool) ([]byte, error)
 			order.User.UserName,
 			order.User.FirstName,
 			order.User.LastName,
-		}
+			i18n.T(lang, "source."+order.Source),
+			order.CustomerName,
+			order.CustomerPhone,
+		)
 		if err := writer.Write(record); err != nil {
 			return nil, err
 		}
@@ -134,16 +192,30 @@ func (b *Bot) createCSV(orders []models.Order, onlyCurrent bool) ([]byte, error)
 	return buf.Bytes(), nil
 }
 
-// getExportTypeDescription возвращает описание типа экспорта
-func (b *Bot) getExportTypeDescription(onlyCurrent bool) string {
-	if onlyCurrent {
-		return "только актуальные"
+// loggiaCodes записывает лоджии заказа для CSV: «тип створки [остекление]» через «; »,
+// например "standard 3 warm; floor 6_7". В этом же виде лоджии принимает импорт.
+func loggiaCodes(loggias []models.Loggia) string {
+	codes := make([]string, len(loggias))
+	for i, loggia := range loggias {
+		codes[i] = strings.TrimSpace(loggia.Type + " " + loggia.Sash + " " + loggia.Glazing)
 	}
-	return "все заказы"
+	return strings.Join(codes, "; ")
 }
 
-// isAdmin проверяет, является ли пользователь администратором
-func (b *Bot) isAdmin(chatID int64) bool {
-	adminID, _ := strconv.ParseInt(os.Getenv("ADMIN_TELEGRAM_ID"), 10, 64)
-	return chatID == adminID
+// addonCodes записывает коды дополнительных услуг заказа через «; », например "frames; nets".
+// Количество не пишется: оно следует из окон и лоджий заказа.
+func addonCodes(addons []models.OrderAddon) string {
+	codes := make([]string, len(addons))
+	for i, addon := range addons {
+		codes[i] = addon.AddonType
+	}
+	return strings.Join(codes, "; ")
+}
+
+// getExportTypeDescription возвращает описание типа экспорта на языке lang
+func getExportTypeDescription(lang string, onlyCurrent bool) string {
+	if onlyCurrent {
+		return i18n.T(lang, "export.type_current")
+	}
+	return i18n.T(lang, "export.type_all")
 }
@@ -0,0 +1,317 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatXLSX   = "xlsx"
+	exportFormatJSON   = "json"
+	exportFormatNDJSON = "ndjson"
+)
+
+// isExportFormat проверяет, поддерживается ли формат выгрузки
+func isExportFormat(format string) bool {
+	switch format {
+	case exportFormatCSV, exportFormatXLSX, exportFormatJSON, exportFormatNDJSON:
+		return true
+	}
+	return false
+}
+
+// exportRequest — фильтры и формат выгрузки
+type exportRequest struct {
+	Filter models.ExportFilter
+	Format string
+	Period string // выбранный в мастере период: "all", "today", "week", "month"
+	Lang   string // язык заголовков CSV; пустой — язык администратора
+}
+
+// neverCurrentStatus сообщает, что заказы с этим статусом не бывают актуальными:
+// отмененный заказ перестает быть заказом квартиры, а заявка становится им только после
+// принятия цены. Выгрузка таких заказов всегда включает неактуальные.
+func neverCurrentStatus(status string) bool {
+	return status == "canceled" || status == models.StatusAwaitingQuote
+}
+
+func defaultExportRequest() exportRequest {
+	return exportRequest{
+		Filter: models.ExportFilter{OnlyCurrent: true},
+		Format: exportFormatCSV,
+		Period: "all",
+	}
+}
+
+// parseExportArgs разбирает аргументы /export. Поддерживаются пары ключ=значение
+// (from, to, entrance, status, format, scope, lang) и короткие слова "все"/"all", "csv"/"xlsx"/"json"/"ndjson".
+// Сообщения об ошибках возвращаются на языке lang.
+func parseExportArgs(lang, args string, now time.Time) (exportRequest, error) {
+	req := defaultExportRequest()
+	req.Period = ""
+
+	var to time.Time
+	var explicitCurrent bool
+	for _, field := range strings.Fields(strings.ToLower(args)) {
+		key, value, hasKey := strings.Cut(field, "=")
+		if !hasKey {
+			switch field {
+			case "все", "all":
+				req.Filter.OnlyCurrent = false
+			case "актуальные", "current":
+				req.Filter.OnlyCurrent = true
+				explicitCurrent = true
+			default:
+				if isExportFormat(field) {
+					req.Format = field
+					continue
+				}
+				return req, errors.New(i18n.T(lang, "export.err_argument", field))
+			}
+			continue
+		}
+
+		switch key {
+		case "from", "с":
+			from, err := time.ParseInLocation("2006-01-02", value, now.Location())
+			if err != nil {
+				return req, errors.New(i18n.T(lang, "export.err_date", value))
+			}
+			req.Filter.From = from
+		case "to", "по":
+			date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+			if err != nil {
+				return req, errors.New(i18n.T(lang, "export.err_date", value))
+			}
+			to = date
+			// Дата окончания включается в выгрузку
+			req.Filter.To = date.AddDate(0, 0, 1)
+		case "entrance", "подъезд":
+			entrance, err := strconv.Atoi(value)
+			if err != nil || entrance < 1 || entrance > maxEntrance {
+				return req, errors.New(i18n.T(lang, "export.err_entrance", value, maxEntrance))
+			}
+			req.Filter.Entrance = entrance
+		case "status", "статус":
+			if !orderStatuses[value] {
+				return req, errors.New(i18n.T(lang, "export.err_status", value))
+			}
+			req.Filter.Status = value
+		case "format", "формат":
+			if !isExportFormat(value) {
+				return req, errors.New(i18n.T(lang, "export.err_format", value))
+			}
+			req.Format = value
+		case "lang", "язык":
+			if !i18n.IsSupported(value) {
+				return req, errors.New(i18n.T(lang, "export.err_lang", value, strings.Join(i18n.Languages, ", ")))
+			}
+			req.Lang = value
+		case "scope":
+			switch value {
+			case "current":
+				req.Filter.OnlyCurrent = true
+				explicitCurrent = true
+			case "all":
+				req.Filter.OnlyCurrent = false
+			default:
+				return req, errors.New(i18n.T(lang, "export.err_scope"))
+			}
+		default:
+			return req, errors.New(i18n.T(lang, "export.err_param", key))
+		}
+	}
+
+	if !req.Filter.From.IsZero() && !to.IsZero() && to.Before(req.Filter.From) {
+		return req, errors.New(i18n.T(lang, "export.err_range"))
+	}
+	if neverCurrentStatus(req.Filter.Status) {
+		if explicitCurrent && req.Filter.OnlyCurrent {
+			return req, errors.New(i18n.T(lang, "export.err_status_scope", i18n.T(lang, "status."+req.Filter.Status)))
+		}
+		req.Filter.OnlyCurrent = false
+	}
+
+	return req, nil
+}
+
+// describeExportFilter возвращает описание фильтров для подписи к файлу на языке lang
+func describeExportFilter(lang string, filter models.ExportFilter) string {
+	parts := []string{getExportTypeDescription(lang, filter.OnlyCurrent)}
+
+	switch {
+	case !filter.From.IsZero() && !filter.To.IsZero():
+		parts = append(parts, i18n.T(lang, "export.filter_range",
+			filter.From.Format("02.01.2006"), filter.To.AddDate(0, 0, -1).Format("02.01.2006")))
+	case !filter.From.IsZero():
+		parts = append(parts, i18n.T(lang, "export.filter_from", filter.From.Format("02.01.2006")))
+	case !filter.To.IsZero():
+		parts = append(parts, i18n.T(lang, "export.filter_to", filter.To.AddDate(0, 0, -1).Format("02.01.2006")))
+	}
+	if filter.Entrance != 0 {
+		parts = append(parts, i18n.T(lang, "export.filter_entrance", filter.Entrance))
+	}
+	if filter.Status != "" {
+		parts = append(parts, i18n.T(lang, "export.filter_status", i18n.T(lang, "status."+filter.Status)))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// sendExportWizard показывает мастер выгрузки с кнопками выбора фильтров
+func (b *Bot) sendExportWizard(chatID int64) {
+	req := defaultExportRequest()
+	req.Lang = b.lang(chatID)
+	b.getSession(chatID).TempData["export_request"] = req
+	b.sendMessage(chatID, exportWizardText(req.Lang, req), exportWizardKeyboard(req.Lang, req))
+}
+
+// handleExportCallback обрабатывает кнопки мастера выгрузки
+func (b *Bot) handleExportCallback(callback *tgbotapi.CallbackQuery) {
+	chatID := callback.Message.Chat.ID
+	data := callback.Data
+	if !b.requirePermission(chatID, PermExport) {
+		return
+	}
+
+	lang := b.lang(chatID)
+	session := b.getSession(chatID)
+	req, ok := session.TempData["export_request"].(exportRequest)
+	if !ok {
+		req = defaultExportRequest()
+		req.Lang = lang
+	}
+
+	today := clock.Now()
+	today = clock.StartOfDay(today)
+
+	switch {
+	case strings.HasPrefix(data, "ex_period_"):
+		req.Period = data[len("ex_period_"):]
+		req.Filter.From, req.Filter.To = time.Time{}, time.Time{}
+		switch req.Period {
+		case "today":
+			req.Filter.From = today
+		case "week":
+			req.Filter.From = today.AddDate(0, 0, -6)
+		case "month":
+			req.Filter.From = today.AddDate(0, -1, 1)
+		default:
+			req.Period = "all"
+		}
+		if req.Period != "all" {
+			req.Filter.To = today.AddDate(0, 0, 1)
+		}
+	case strings.HasPrefix(data, "ex_ent_"):
+		entrance, err := strconv.Atoi(data[len("ex_ent_"):])
+		if err != nil || entrance < 0 || entrance > maxEntrance {
+			return
+		}
+		req.Filter.Entrance = entrance
+	case strings.HasPrefix(data, "ex_st_"):
+		status := data[len("ex_st_"):]
+		if !orderStatuses[status] && status != "" {
+			return
+		}
+		req.Filter.Status = status
+		if neverCurrentStatus(status) {
+			req.Filter.OnlyCurrent = false
+		}
+	case data == "ex_scope":
+		req.Filter.OnlyCurrent = !req.Filter.OnlyCurrent
+		if req.Filter.OnlyCurrent && neverCurrentStatus(req.Filter.Status) {
+			req.Filter.Status = ""
+		}
+	case strings.HasPrefix(data, "ex_fmt_"):
+		format := data[len("ex_fmt_"):]
+		if !isExportFormat(format) {
+			return
+		}
+		req.Format = format
+	case data == "ex_go":
+		delete(session.TempData, "export_request")
+		b.sendExport(chatID, req)
+		return
+	}
+
+	session.TempData["export_request"] = req
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, callback.Message.MessageID,
+		exportWizardText(lang, req), exportWizardKeyboard(lang, req))
+	if _, err := b.api.Send(edit); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("⚠️ Ошибка обновления мастера выгрузки: %v", err)
+	}
+}
+
+func exportWizardText(lang string, req exportRequest) string {
+	return i18n.T(lang, "export.wizard", describeExportFilter(lang, req.Filter), strings.ToUpper(req.Format))
+}
+
+func exportWizardKeyboard(lang string, req exportRequest) tgbotapi.InlineKeyboardMarkup {
+	// mark отмечает выбранный вариант
+	mark := func(selected bool, title string) string {
+		if selected {
+			return "✅ " + title
+		}
+		return title
+	}
+
+	periodRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Period == "all", i18n.T(lang, "export.btn_period_all")), "ex_period_all"),
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Period == "today", i18n.T(lang, "export.btn_period_today")), "ex_period_today"),
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Period == "week", i18n.T(lang, "export.btn_period_week")), "ex_period_week"),
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Period == "month", i18n.T(lang, "export.btn_period_month")), "ex_period_month"),
+	)
+
+	entranceRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Filter.Entrance == 0, i18n.T(lang, "export.btn_all_entrances")), "ex_ent_0"),
+	)
+	for entrance := 1; entrance <= maxEntrance; entrance++ {
+		entranceRow = append(entranceRow, tgbotapi.NewInlineKeyboardButtonData(
+			mark(req.Filter.Entrance == entrance, strconv.Itoa(entrance)), fmt.Sprintf("ex_ent_%d", entrance)))
+	}
+
+	statusRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(mark(req.Filter.Status == "", i18n.T(lang, "export.btn_all_statuses")), "ex_st_"),
+	)
+	for _, status := range []string{"confirmed", "needs_clarification", "awaiting_quote", "canceled"} {
+		statusRow = append(statusRow, tgbotapi.NewInlineKeyboardButtonData(
+			mark(req.Filter.Status == status, i18n.T(lang, "status."+status)), "ex_st_"+status))
+	}
+
+	scopeTitle := i18n.T(lang, "export.btn_scope_current")
+	if !req.Filter.OnlyCurrent {
+		scopeTitle = i18n.T(lang, "export.btn_scope_all")
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		periodRow,
+		entranceRow,
+		statusRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔁 "+scopeTitle, "ex_scope"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark(req.Format == exportFormatCSV, "CSV"), "ex_fmt_csv"),
+			tgbotapi.NewInlineKeyboardButtonData(mark(req.Format == exportFormatXLSX, "Excel"), "ex_fmt_xlsx"),
+			tgbotapi.NewInlineKeyboardButtonData(mark(req.Format == exportFormatJSON, "JSON"), "ex_fmt_json"),
+			tgbotapi.NewInlineKeyboardButtonData(mark(req.Format == exportFormatNDJSON, "NDJSON"), "ex_fmt_ndjson"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "export.btn_download"), "ex_go"),
+		),
+	)
+}
+
+func exportUsage(lang string) string {
+	return i18n.T(lang, "export.usage", maxEntrance)
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+func TestParseExportArgs(t *testing.T) {
+	now := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		args    string
+		want    exportRequest
+		wantErr bool
+	}{
+		{
+			args: "",
+			want: exportRequest{Filter: models.ExportFilter{OnlyCurrent: true}, Format: exportFormatCSV},
+		},
+		{
+			args: "all xlsx",
+			want: exportRequest{Format: exportFormatXLSX},
+		},
+		{
+			args: "Все JSON",
+			want: exportRequest{Format: exportFormatJSON},
+		},
+		{
+			args: "from=2026-04-01 to=2026-04-30 entrance=3 status=confirmed format=ndjson",
+			want: exportRequest{
+				Filter: models.ExportFilter{
+					OnlyCurrent: true,
+					From:        date(4, 1),
+					To:          date(5, 1),
+					Entrance:    3,
+					Status:      "confirmed",
+				},
+				Format: exportFormatNDJSON,
+			},
+		},
+		{
+			args: "с=2026-04-10 по=2026-04-10 подъезд=6 статус=awaiting_quote",
+			want: exportRequest{
+				Filter: models.ExportFilter{
+					From:     date(4, 10),
+					To:       date(4, 11),
+					Entrance: 6,
+					Status:   "awaiting_quote",
+				},
+				Format: exportFormatCSV,
+			},
+		},
+		{
+			args: "scope=all lang=en",
+			want: exportRequest{Format: exportFormatCSV, Lang: "en"},
+		},
+		{
+			args: "status=canceled",
+			want: exportRequest{Filter: models.ExportFilter{Status: "canceled"}, Format: exportFormatCSV},
+		},
+		{
+			args: "scope=all status=canceled xlsx",
+			want: exportRequest{Filter: models.ExportFilter{Status: "canceled"}, Format: exportFormatXLSX},
+		},
+		{
+			args: "current status=canceled all",
+			want: exportRequest{Filter: models.ExportFilter{Status: "canceled"}, Format: exportFormatCSV},
+		},
+		{
+			args: "all current",
+			want: exportRequest{Filter: models.ExportFilter{OnlyCurrent: true}, Format: exportFormatCSV},
+		},
+		{args: "pdf", wantErr: true},
+		{args: "format=pdf", wantErr: true},
+		{args: "from=01.04.2026", wantErr: true},
+		{args: "to=2026-13-01", wantErr: true},
+		{args: "from=2026-04-10 to=2026-04-01", wantErr: true},
+		{args: "entrance=0", wantErr: true},
+		{args: "entrance=7", wantErr: true},
+		{args: "entrance=первый", wantErr: true},
+		{args: "status=lost", wantErr: true},
+		{args: "status=canceled scope=current", wantErr: true},
+		{args: "актуальные статус=awaiting_quote", wantErr: true},
+		{args: "lang=de", wantErr: true},
+		{args: "scope=some", wantErr: true},
+		{args: "color=red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, err := parseExportArgs(i18n.Default, tt.args, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExportArgs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseExportArgs(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// Ошибки разбора возвращаются на языке администратора
+func TestParseExportArgsErrorLanguage(t *testing.T) {
+	now := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+	for _, lang := range i18n.Languages {
+		_, err := parseExportArgs(lang, "entrance=9", now)
+		if err == nil {
+			t.Fatalf("%s: expected an error", lang)
+		}
+		if want := i18n.T(lang, "export.err_entrance", "9", maxEntrance); err.Error() != want {
+			t.Errorf("%s: error = %q, want %q", lang, err.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/storage"
+)
+
+// ExportSchemaVersion — версия схемы JSON/NDJSON-выгрузки (описана в docs/export-json.md).
+// Новые поля добавляются без смены версии; версия увеличивается, только если поле
+// удаляется, переименовывается или меняет смысл.
+const ExportSchemaVersion = 1
+
+// jsonExport — документ выгрузки в формате JSON
+type jsonExport struct {
+	SchemaVersion int         `json:"schema_version"`
+	GeneratedAt   time.Time   `json:"generated_at"`
+	Timezone      string      `json:"timezone"`
+	Filter        jsonFilter  `json:"filter"`
+	Orders        []jsonOrder `json:"orders"`
+}
+
+type jsonFilter struct {
+	OnlyCurrent bool    `json:"only_current"`
+	From        *string `json:"from"` // включительно, ГГГГ-ММ-ДД
+	To          *string `json:"to"`   // включительно, ГГГГ-ММ-ДД
+	Entrance    *int    `json:"entrance"`
+	Status      *string `json:"status"`
+}
+
+// jsonOrder — заказ; в NDJSON каждая строка — такой объект с полем schema_version
+type jsonOrder struct {
+	SchemaVersion int               `json:"schema_version,omitempty"`
+	ID            int64             `json:"id"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Status        string            `json:"status"`
+	IsCurrent     bool              `json:"is_current"`
+	Source        string            `json:"source"`
+	CreatedBy     *int64            `json:"created_by"`
+	Address       jsonAddress       `json:"address"`
+	Customer      jsonCustomer      `json:"customer"`
+	Items         []jsonItem        `json:"items"`
+	Description   *string           `json:"description"` // описание нестандартных окон из заявки на расчет
+	Comment       *string           `json:"comment"`
+	Access        jsonAccess        `json:"access"`
+	Price         jsonPrice         `json:"price"`
+	StatusHistory []jsonStatusEvent `json:"status_history"`
+}
+
+type jsonAddress struct {
+	Entrance  int    `json:"entrance"`
+	Floor     int    `json:"floor"`
+	Apartment string `json:"apartment"`
+}
+
+type jsonCustomer struct {
+	TelegramID   *int64  `json:"telegram_id"`
+	Username     *string `json:"username"`
+	FirstName    *string `json:"first_name"`
+	LastName     *string `json:"last_name"`
+	TelegramNick *string `json:"telegram_nick"`
+	TelegramLink *string `json:"telegram_link"`
+	Name         *string `json:"name"`
+	Phone        *string `json:"phone"`
+}
+
+type jsonAccess struct {
+	IntercomCode     *string `json:"intercom_code"`
+	PresenceRequired bool    `json:"presence_required"`
+	PreferredTime    *string `json:"preferred_time"` // "morning", "afternoon", "evening"; null — в любое время
+}
+
+type jsonItem struct {
+	Kind       string  `json:"kind"`      // "window", "loggia", "item" или "addon"
+	ItemType   *string `json:"item_type"` // код позиции каталога изделий или дополнительной услуги
+	Sash       *string `json:"sash"`      // "3", "4", "5", "6_7"
+	LoggiaType *string `json:"loggia_type"`
+	Glazing    *string `json:"glazing"`
+	Quantity   int     `json:"quantity"`
+	UnitPrice  int     `json:"unit_price"`
+	Total      int     `json:"total"`
+}
+
+type jsonPrice struct {
+	Currency   string `json:"currency"`
+	ItemsTotal int    `json:"items_total"` // сумма позиций по текущему прайсу
+	Adjustment int    `json:"adjustment"`  // ручная корректировка администратора
+	Total      int    `json:"total"`       // итоговая стоимость заказа
+}
+
+type jsonStatusEvent struct {
+	Status    string    `json:"status"`
+	ChangedAt time.Time `json:"changed_at"`
+	ChangedBy *int64    `json:"changed_by"`
+}
+
+// createJSON формирует выгрузку в формате JSON или NDJSON (по заказу на строку)
+func createJSON(db *storage.Postgres, orders []models.Order, filter models.ExportFilter, ndjson bool) ([]byte, error) {
+	ids := make([]int64, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	history, err := db.GetStatusHistory(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonOrders := make([]jsonOrder, len(orders))
+	for i, order := range orders {
+		jsonOrders[i] = newJSONOrder(order, history[order.ID])
+	}
+
+	if ndjson {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		for _, order := range jsonOrders {
+			order.SchemaVersion = ExportSchemaVersion
+			if err := encoder.Encode(order); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+
+	doc := jsonExport{
+		SchemaVersion: ExportSchemaVersion,
+		GeneratedAt:   clock.Now().Truncate(time.Second),
+		Timezone:      clock.Location().String(),
+		Filter:        newJSONFilter(filter),
+		Orders:        jsonOrders,
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newJSONFilter(filter models.ExportFilter) jsonFilter {
+	result := jsonFilter{OnlyCurrent: filter.OnlyCurrent}
+	if !filter.From.IsZero() {
+		from := filter.From.Format("2006-01-02")
+		result.From = &from
+	}
+	if !filter.To.IsZero() {
+		to := filter.To.AddDate(0, 0, -1).Format("2006-01-02")
+		result.To = &to
+	}
+	if filter.Entrance != 0 {
+		result.Entrance = &filter.Entrance
+	}
+	if filter.Status != "" {
+		result.Status = &filter.Status
+	}
+	return result
+}
+
+func newJSONOrder(order models.Order, history []models.OrderStatusChange) jsonOrder {
+	result := jsonOrder{
+		ID:        order.ID,
+		CreatedAt: order.CreatedAt.In(clock.Location()),
+		Status:    order.Status,
+		IsCurrent: order.IsCurrent,
+		Source:    order.Source,
+		CreatedBy: optionalID(order.CreatedBy),
+		Address: jsonAddress{
+			Entrance:  order.Entrance,
+			Floor:     order.Floor,
+			Apartment: order.Apartment,
+		},
+		Customer: jsonCustomer{
+			TelegramID:   optionalID(order.UserID),
+			TelegramNick: optionalString(order.TelegramNick),
+			Name:         optionalString(order.CustomerName),
+			Phone:        optionalString(order.CustomerPhone),
+		},
+		Items:         []jsonItem{},
+		Description:   optionalString(order.Description),
+		Comment:       optionalString(order.Comment),
+		StatusHistory: []jsonStatusEvent{},
+	}
+	result.Access = jsonAccess{
+		IntercomCode:     optionalString(order.IntercomCode),
+		PresenceRequired: order.Presence,
+		PreferredTime:    optionalString(order.PreferredTime),
+	}
+	if order.UserID != 0 {
+		result.Customer.TelegramLink = optionalString(TelegramLink(order.UserID, order.User.UserName))
+		result.Customer.Username = optionalString(order.User.UserName)
+		result.Customer.FirstName = optionalString(order.User.FirstName)
+		result.Customer.LastName = optionalString(order.User.LastName)
+	}
+
+	// Позиции каталога — по цене, с которой они вошли в заказ
+	for _, item := range order.Items {
+		kind, sash := "item", ""
+		if itemType, ok := findItem(item.ItemType); ok && itemType.Sash != "" {
+			kind, sash = "window", itemType.Sash
+		}
+		result.Items = append(result.Items, jsonItem{
+			Kind: kind, ItemType: optionalString(item.ItemType), Sash: optionalString(sash),
+			Quantity: item.Quantity, UnitPrice: item.UnitPrice, Total: item.UnitPrice * item.Quantity,
+		})
+	}
+	// Каждая лоджия — отдельная позиция: у лоджий одного заказа могут быть разные окна
+	for _, loggia := range order.Loggias {
+		price := LoggiaPrice(loggia)
+		result.Items = append(result.Items, jsonItem{
+			Kind: "loggia", Sash: optionalString(loggia.Sash), LoggiaType: optionalString(loggia.Type),
+			Glazing: optionalString(loggia.Glazing), Quantity: 1, UnitPrice: price, Total: price,
+		})
+	}
+
+	for _, addon := range order.Addons {
+		result.Items = append(result.Items, jsonItem{
+			Kind: "addon", ItemType: optionalString(addon.AddonType),
+			Quantity: addon.Quantity, UnitPrice: addon.UnitPrice, Total: addon.UnitPrice * addon.Quantity,
+		})
+	}
+
+	itemsTotal := 0
+	for _, item := range result.Items {
+		itemsTotal += item.Total
+	}
+	result.Price = jsonPrice{
+		Currency:   "RUB",
+		ItemsTotal: itemsTotal,
+		Adjustment: order.Price - itemsTotal,
+		Total:      order.Price,
+	}
+
+	for _, change := range history {
+		result.StatusHistory = append(result.StatusHistory, jsonStatusEvent{
+			Status:    change.Status,
+			ChangedAt: change.ChangedAt.In(clock.Location()),
+			ChangedBy: optionalID(change.ChangedBy),
+		})
+	}
+	return result
+}
+
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,234 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/xlsx"
+)
+
+// createXLSX создает книгу Excel: лист заказов и сводки по подъездам и позициям каталога
+func (b *Bot) createXLSX(orders []models.Order) ([]byte, error) {
+	wb := xlsx.NewWorkbook()
+
+	addOrdersSheet(wb, orders)
+	addEntranceSummarySheet(wb, orders)
+	addItemSummarySheet(wb, orders)
+
+	return wb.Bytes()
+}
+
+func addOrdersSheet(wb *xlsx.Workbook, orders []models.Order) {
+	sheet := wb.AddSheet("Заказы")
+	sheet.FreezeHeader = true
+	sheet.AutoFilter = true
+
+	// По колонке на каждую позицию каталога изделий
+	items := catalogItems()
+	headers := []string{"№ заказа", "Дата создания", "Подъезд", "Этаж", "Квартира", "Одинаковые створки"}
+	widths := []float64{10, 17, 9, 7, 10, 12}
+	for _, item := range items {
+		headers = append(headers, itemTitle(i18n.Default, item))
+		widths = append(widths, 8)
+	}
+	headers = append(headers, "Лоджии", "Состав лоджий", "Доп. услуги", "Описание", "Комментарий",
+		"Код домофона", "Нужно присутствие", "Удобное время", "Стоимость", "Статус", "Актуальный",
+		"Клиент", "ID пользователя", "Username", "Ник из заказа", "Телефон", "Источник")
+	widths = append(widths, 8, 40, 30, 40, 40, 12, 11, 12, 12, 15, 11, 30, 14, 18, 18, 16, 10)
+
+	header := make([]xlsx.Cell, len(headers))
+	for i, h := range headers {
+		header[i] = xlsx.Header(h)
+		sheet.SetColumnWidth(i, widths[i])
+	}
+	sheet.AddRow(header...)
+
+	for _, order := range orders {
+		loggias := make([]string, len(order.Loggias))
+		for i, loggia := range order.Loggias {
+			loggias[i] = loggiaTitle(i18n.Default, loggia)
+		}
+		addons := make([]string, len(order.Addons))
+		for i, addon := range order.Addons {
+			addons[i] = fmt.Sprintf("%s × %d", addonTitleByCode(i18n.Default, addon.AddonType), addon.Quantity)
+		}
+
+		userID := xlsx.Empty()
+		if order.UserID != 0 {
+			userID = xlsx.Text(formatID(order.UserID))
+		}
+		client := order.CustomerName
+		if order.UserID != 0 {
+			client = order.User.FirstName + " " + order.User.LastName
+		}
+
+		row := []xlsx.Cell{
+			xlsx.Int(int(order.ID)),
+			xlsx.Date(order.CreatedAt.In(clock.Location())),
+			xlsx.Int(order.Entrance),
+			xlsx.Int(order.Floor),
+			xlsx.Text(order.Apartment),
+			xlsx.Text(yesNo(i18n.Default, order.WindowsSame)),
+		}
+		for _, item := range items {
+			row = append(row, xlsx.Int(order.Quantity(item.Code)))
+		}
+		row = append(row,
+			xlsx.Int(len(order.Loggias)),
+			xlsx.Text(strings.Join(loggias, "; ")),
+			xlsx.Text(strings.Join(addons, "; ")),
+			xlsx.Text(order.Description),
+			xlsx.Text(order.Comment),
+			xlsx.Text(order.IntercomCode),
+			xlsx.Text(yesNo(i18n.Default, order.Presence)),
+			xlsx.Text(preferredTimeTitle(order.PreferredTime)),
+			xlsx.Money(order.Price),
+			xlsx.Text(i18n.T(i18n.Default, "status."+order.Status)),
+			xlsx.Text(yesNo(i18n.Default, order.IsCurrent)),
+			xlsx.Text(client),
+			userID,
+			xlsx.Text(order.User.UserName),
+			xlsx.Text(order.TelegramNick),
+			xlsx.Text(order.CustomerPhone),
+			xlsx.Text(i18n.T(i18n.Default, "source."+order.Source)),
+		)
+		sheet.AddRow(row...)
+	}
+}
+
+// addEntranceSummarySheet добавляет сводку по подъездам (учитываются только подтвержденные
+// актуальные заказы)
+func addEntranceSummarySheet(wb *xlsx.Workbook, orders []models.Order) {
+	type entranceSummary struct {
+		orders, windows, loggias, revenue int
+	}
+	summary := make(map[int]*entranceSummary)
+	for _, order := range orders {
+		if !order.CountsInRevenue() {
+			continue
+		}
+		s, ok := summary[order.Entrance]
+		if !ok {
+			s = &entranceSummary{}
+			summary[order.Entrance] = s
+		}
+		s.orders++
+		s.windows += order.ItemCount()
+		s.loggias += len(order.Loggias)
+		s.revenue += order.Price
+	}
+
+	entrances := make([]int, 0, len(summary))
+	for entrance := range summary {
+		entrances = append(entrances, entrance)
+	}
+	sort.Ints(entrances)
+
+	sheet := wb.AddSheet("По подъездам")
+	sheet.FreezeHeader = true
+	for i, width := range []float64{12, 10, 10, 10, 14} {
+		sheet.SetColumnWidth(i, width)
+	}
+	sheet.AddRow(xlsx.Header("Подъезд"), xlsx.Header("Заказов"), xlsx.Header("Окон"),
+		xlsx.Header("Лоджий"), xlsx.Header("Выручка"))
+
+	var total entranceSummary
+	for _, entrance := range entrances {
+		s := summary[entrance]
+		sheet.AddRow(xlsx.Int(entrance), xlsx.Int(s.orders), xlsx.Int(s.windows),
+			xlsx.Int(s.loggias), xlsx.Money(s.revenue))
+		total.orders += s.orders
+		total.windows += s.windows
+		total.loggias += s.loggias
+		total.revenue += s.revenue
+	}
+	sheet.AddRow(xlsx.Header("Итого"), xlsx.Int(total.orders), xlsx.Int(total.windows),
+		xlsx.Int(total.loggias), xlsx.Money(total.revenue))
+}
+
+// addItemSummarySheet добавляет сводку по позициям каталога изделий и дополнительным услугам;
+// лоджии учитываются в строке окна с теми же створками, а лоджии, створкам которых нет окна
+// в каталоге, — в строке «Прочие лоджии» (учитываются только подтвержденные
+// актуальные заказы)
+func addItemSummarySheet(wb *xlsx.Workbook, orders []models.Order) {
+	items := catalogItems()
+	counts := make(map[string]int)
+	loggias := make(map[string]int)
+	revenue := make(map[string]int)
+	addonCounts := make(map[string]int)
+	addonRevenue := make(map[string]int)
+
+	sashItems := make(map[string]string)
+	for _, item := range items {
+		if item.Sash != "" {
+			sashItems[item.Sash] = item.Code
+		}
+	}
+
+	for _, order := range orders {
+		if !order.CountsInRevenue() {
+			continue
+		}
+		for _, item := range order.Items {
+			counts[item.ItemType] += item.Quantity
+			revenue[item.ItemType] += item.Quantity * item.UnitPrice
+		}
+		for _, loggia := range order.Loggias {
+			code := sashItems[loggia.Sash]
+			loggias[code]++
+			revenue[code] += LoggiaPrice(loggia)
+		}
+		for _, addon := range order.Addons {
+			addonCounts[addon.AddonType] += addon.Quantity
+			addonRevenue[addon.AddonType] += addon.Quantity * addon.UnitPrice
+		}
+	}
+
+	sheet := wb.AddSheet("По изделиям")
+	sheet.FreezeHeader = true
+	for i, width := range []float64{18, 10, 10, 14} {
+		sheet.SetColumnWidth(i, width)
+	}
+	sheet.AddRow(xlsx.Header("Тип"), xlsx.Header("Количество"), xlsx.Header("Лоджий"), xlsx.Header("Выручка"))
+
+	var totalCount, totalLoggias, totalRevenue int
+	for _, item := range items {
+		code := item.Code
+		sheet.AddRow(xlsx.Text(itemTitle(i18n.Default, item)), xlsx.Int(counts[code]),
+			xlsx.Int(loggias[code]), xlsx.Money(revenue[code]))
+		totalCount += counts[code]
+		totalLoggias += loggias[code]
+		totalRevenue += revenue[code]
+	}
+	if loggias[""] > 0 {
+		sheet.AddRow(xlsx.Text("Прочие лоджии"), xlsx.Empty(), xlsx.Int(loggias[""]), xlsx.Money(revenue[""]))
+		totalLoggias += loggias[""]
+		totalRevenue += revenue[""]
+	}
+	for _, addon := range catalogAddons() {
+		code := addon.Code
+		sheet.AddRow(xlsx.Text(addonTitle(i18n.Default, addon)), xlsx.Int(addonCounts[code]),
+			xlsx.Empty(), xlsx.Money(addonRevenue[code]))
+		totalRevenue += addonRevenue[code]
+	}
+	sheet.AddRow(xlsx.Header("Итого"), xlsx.Int(totalCount), xlsx.Int(totalLoggias), xlsx.Money(totalRevenue))
+}
+
+func yesNo(lang string, v bool) string {
+	if v {
+		return i18n.T(lang, "common.yes")
+	}
+	return i18n.T(lang, "common.no")
+}
+
+// sashTitle возвращает количество створок для отображения: "6_7" → "6-7"
+func sashTitle(sash string) string {
+	if sash == "6_7" {
+		return "6-7"
+	}
+	return sash
+}
@@ -0,0 +1,520 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	StateAdminEditOrder       = "admin_edit_order"
+	StateAdminMessageCustomer = "admin_message_customer"
+	StateAdminQuotePrice      = "admin_quote_price"
+)
+
+// adminInput — ожидаемый от администратора ввод по заказу: новое значение поля, цена заявки
+// или сообщение клиенту. Хранится отдельно от сессии, чтобы не прерывать диалог заказа,
+// который администратор ведет в том же чате.
+type adminInput struct {
+	State   string
+	OrderID int64
+	Field   string
+}
+
+var adminInputs = make(map[int64]*adminInput)
+
+// findPageSize — количество заказов на одной странице результатов /find
+const findPageSize = 5
+
+// adminOrderStatuses — статусы, которые администратор может назначить кнопками карточки заказа.
+// Статус заявки на расчет меняется только через назначение и принятие цены.
+var adminOrderStatuses = map[string]bool{
+	"confirmed":           true,
+	"needs_clarification": true,
+	"canceled":            true,
+}
+
+// Поля заказа, которые администратор может изменить
+var editableOrderFields = map[string]string{
+	"entrance":  "Подъезд",
+	"floor":     "Этаж",
+	"apartment": "Квартира",
+	"price":     "Стоимость",
+}
+
+// handleFind обрабатывает команду /find <запрос>
+func (b *Bot) handleFind(msg *tgbotapi.Message) {
+	if !b.requirePermission(msg.Chat.ID, PermFindOrders) {
+		return
+	}
+
+	query := strings.TrimSpace(msg.CommandArguments())
+	if query == "" {
+		b.sendMessage(msg.Chat.ID, findUsage())
+		return
+	}
+
+	b.sendFindResults(msg.Chat.ID, query, 0)
+}
+
+// parseFindQuery разбирает запрос поиска. Поддерживаются:
+// "123" — квартира, "#45" — ID заказа, "@nick" — username, "3/12" — подъезд/этаж,
+// число больше maxApartment — Telegram ID, а также пары id=, tg=, user=, entrance=, floor=, apt=.
+func parseFindQuery(query string) (models.OrderSearch, error) {
+	var search models.OrderSearch
+
+	for _, field := range strings.Fields(query) {
+		key, value, hasKey := strings.Cut(field, "=")
+		if !hasKey {
+			key, value = "", field
+		}
+
+		switch {
+		case key == "" && strings.HasPrefix(value, "@"):
+			key, value = "user", value[1:]
+		case key == "" && strings.HasPrefix(value, "#"):
+			key, value = "id", value[1:]
+		case key == "" && strings.Contains(value, "/"):
+			entrance, floor, _ := strings.Cut(value, "/")
+			e, err1 := strconv.Atoi(entrance)
+			f, err2 := strconv.Atoi(floor)
+			if err1 != nil || err2 != nil {
+				return search, fmt.Errorf("не удалось разобрать «%s» как подъезд/этаж", value)
+			}
+			search.Entrance, search.Floor = e, f
+			continue
+		case key == "":
+			number, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return search, fmt.Errorf("не удалось разобрать «%s»", value)
+			}
+			if number > maxApartment {
+				key = "tg"
+			} else {
+				key = "apt"
+			}
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "id", "order":
+			search.OrderID, err = strconv.ParseInt(value, 10, 64)
+		case "tg", "telegram":
+			search.TelegramID, err = strconv.ParseInt(value, 10, 64)
+		case "user", "username":
+			search.UserName = strings.TrimPrefix(value, "@")
+		case "entrance", "подъезд":
+			search.Entrance, err = strconv.Atoi(value)
+		case "floor", "этаж":
+			search.Floor, err = strconv.Atoi(value)
+		case "apt", "apartment", "кв":
+			if !IsDigitsOnly(value) {
+				err = fmt.Errorf("некорректный номер квартиры")
+			}
+			search.Apartment = value
+		default:
+			return search, fmt.Errorf("неизвестный параметр «%s»", key)
+		}
+		if err != nil {
+			return search, fmt.Errorf("некорректное значение «%s»", field)
+		}
+	}
+
+	return search, nil
+}
+
+// sendFindResults отправляет страницу результатов поиска
+func (b *Bot) sendFindResults(chatID int64, query string, page int) {
+	search, err := parseFindQuery(query)
+	if err != nil {
+		b.sendMessage(chatID, "Ошибка: "+err.Error()+"\n\n"+findUsage())
+		return
+	}
+
+	orders, total, err := b.db.FindOrders(search, findPageSize, page*findPageSize)
+	if err != nil {
+		log.Printf("⚠️ Ошибка поиска заказов: %v", err)
+		b.sendMessage(chatID, "Произошла ошибка при поиске заказов.")
+		return
+	}
+	if total == 0 {
+		b.sendMessage(chatID, "Заказы не найдены.")
+		return
+	}
+
+	pages := (total + findPageSize - 1) / findPageSize
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("Найдено заказов: %d (стр. %d/%d)\n\n", total, page+1, pages))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var orderButtons []tgbotapi.InlineKeyboardButton
+	for _, order := range orders {
+		text.WriteString(formatOrderShort(order) + "\n")
+		orderButtons = append(orderButtons, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("№%d", order.ID), fmt.Sprintf("order_view_%d", order.ID)))
+	}
+	rows = append(rows, orderButtons)
+
+	// Запрос передается в callback data, длина которой ограничена 64 байтами.
+	// Для очень длинных запросов листание недоступно.
+	var navButtons []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		if data := fmt.Sprintf("find_page_%d_%s", page-1, query); len(data) <= 64 {
+			navButtons = append(navButtons, tgbotapi.NewInlineKeyboardButtonData("◀️ Назад", data))
+		}
+	}
+	if page+1 < pages {
+		if data := fmt.Sprintf("find_page_%d_%s", page+1, query); len(data) <= 64 {
+			navButtons = append(navButtons, tgbotapi.NewInlineKeyboardButtonData("Вперед ▶️", data))
+		}
+	}
+	if len(navButtons) > 0 {
+		rows = append(rows, navButtons)
+	}
+
+	b.sendMessage(chatID, text.String(), tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// handleAdminOrderCallback обрабатывает кнопки поиска и действий с заказом
+func (b *Bot) handleAdminOrderCallback(chatID int64, data string) {
+	if strings.HasPrefix(data, "find_page_") {
+		if !b.requirePermission(chatID, PermFindOrders) {
+			return
+		}
+		parts := strings.SplitN(data[len("find_page_"):], "_", 2)
+		if len(parts) != 2 {
+			return
+		}
+		page, err := strconv.Atoi(parts[0])
+		if err != nil || page < 0 {
+			return
+		}
+		b.sendFindResults(chatID, parts[1], page)
+		return
+	}
+
+	// Остальные действия имеют вид order_<действие>_<id>[_<параметр>]
+	parts := strings.SplitN(strings.TrimPrefix(data, "order_"), "_", 3)
+	if len(parts) < 2 {
+		return
+	}
+	action := parts[0]
+	orderID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+	param := ""
+	if len(parts) == 3 {
+		param = parts[2]
+	}
+
+	perm := PermManageOrders
+	if action == "view" {
+		perm = PermFindOrders
+	}
+	if !b.requirePermission(chatID, perm) {
+		return
+	}
+
+	order, err := b.db.GetOrderByID(orderID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения заказа: %v", err)
+		b.sendMessage(chatID, "Произошла ошибка при получении заказа.")
+		return
+	}
+	if order == nil {
+		b.sendMessage(chatID, "Заказ не найден.")
+		return
+	}
+
+	switch action {
+	case "view":
+		b.sendOrderDetails(chatID, *order)
+
+	case "status":
+		if !adminOrderStatuses[param] {
+			b.sendMessage(chatID, "Неизвестный статус.")
+			return
+		}
+		if order.Status == models.StatusAwaitingQuote {
+			b.sendMessage(chatID, fmt.Sprintf("Заявка №%d ждет расчета: статус изменится, когда клиент примет цену.", order.ID))
+			return
+		}
+		order.Status = param
+		if err := b.db.UpdateOrder(*order, chatID); err != nil {
+			log.Printf("⚠️ Ошибка изменения статуса: %v", err)
+			b.sendMessage(chatID, "Не удалось изменить статус заказа.")
+			return
+		}
+		b.sendMessage(chatID, fmt.Sprintf("Статус заказа №%d изменен на «%s».", order.ID, i18n.T(i18n.Default, "status."+order.Status)))
+		if order.UserID != 0 {
+			lang := b.lang(order.UserID)
+			b.sendMessage(order.UserID, i18n.T(lang, "notify.status_changed", order.ID, i18n.T(lang, "status."+order.Status)))
+		}
+
+	case "edit":
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for _, field := range []string{"entrance", "floor", "apartment", "price"} {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(editableOrderFields[field],
+					fmt.Sprintf("order_editfield_%d_%s", order.ID, field))))
+		}
+		b.sendMessage(chatID, "Что изменить?", tgbotapi.NewInlineKeyboardMarkup(rows...))
+
+	case "editfield":
+		title, ok := editableOrderFields[param]
+		if !ok {
+			return
+		}
+		adminInputs[chatID] = &adminInput{State: StateAdminEditOrder, OrderID: order.ID, Field: param}
+		b.sendMessage(chatID, fmt.Sprintf("Введите новое значение поля «%s» для заказа №%d:", title, order.ID),
+			adminInputCancelKeyboard(order.ID))
+
+	case "quote":
+		if order.Status != models.StatusAwaitingQuote {
+			b.sendMessage(chatID, fmt.Sprintf("Заявка №%d уже обработана: %s.", order.ID, i18n.T(i18n.Default, "status."+order.Status)))
+			return
+		}
+		adminInputs[chatID] = &adminInput{State: StateAdminQuotePrice, OrderID: order.ID}
+		b.sendMessage(chatID, fmt.Sprintf("Введите стоимость для заявки №%d, руб.:", order.ID),
+			adminInputCancelKeyboard(order.ID))
+
+	case "photos":
+		b.sendOrderPhotos(chatID, order.Photos)
+
+	case "msg":
+		if order.UserID == 0 {
+			b.sendMessage(chatID, fmt.Sprintf("У клиента нет Telegram. Телефон: %s", order.CustomerPhone))
+			return
+		}
+		adminInputs[chatID] = &adminInput{State: StateAdminMessageCustomer, OrderID: order.ID}
+		b.sendMessage(chatID, fmt.Sprintf("Введите сообщение для клиента по заказу №%d:", order.ID),
+			adminInputCancelKeyboard(order.ID))
+
+	case "abort":
+		// Отмена ввода значения, сообщения клиенту или цены заявки
+		if _, ok := adminInputs[chatID]; ok {
+			delete(adminInputs, chatID)
+			b.sendMessage(chatID, "Действие отменено.")
+		}
+	}
+}
+
+// adminInputCancelKeyboard — кнопка отмены под запросом ввода по заказу
+func adminInputCancelKeyboard(orderID int64) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Отмена", fmt.Sprintf("order_abort_%d", orderID)),
+		),
+	)
+}
+
+// handleAdminText обрабатывает текст, введенный администратором при редактировании заказа
+func (b *Bot) handleAdminText(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	input := adminInputs[chatID]
+	if input == nil {
+		return
+	}
+
+	if !b.requirePermission(chatID, PermManageOrders) {
+		delete(adminInputs, chatID)
+		return
+	}
+
+	order, err := b.db.GetOrderByID(input.OrderID)
+	if err != nil || order == nil {
+		log.Printf("⚠️ Ошибка получения заказа %d: %v", input.OrderID, err)
+		b.sendMessage(chatID, "Заказ не найден.")
+		delete(adminInputs, chatID)
+		return
+	}
+
+	switch input.State {
+	case StateAdminEditOrder:
+		field := input.Field
+		value, err := strconv.Atoi(msg.Text)
+		switch {
+		case err != nil:
+			b.sendMessage(chatID, "Введите число:")
+			return
+		case field == "entrance" && (value < 1 || value > maxEntrance):
+			b.sendMessage(chatID, fmt.Sprintf("Некорректный подъезд. Введите цифру от 1 до %d:", maxEntrance))
+			return
+		case field == "floor" && (value < 1 || value > maxFloor):
+			b.sendMessage(chatID, fmt.Sprintf("Некорректный этаж. Введите цифру от 1 до %d:", maxFloor))
+			return
+		case field == "apartment" && (value < 1 || value > maxApartment):
+			b.sendMessage(chatID, fmt.Sprintf("Некорректный номер квартиры. Введите цифру от 1 до %d:", maxApartment))
+			return
+		case field == "price" && value < 0:
+			b.sendMessage(chatID, "Стоимость не может быть отрицательной:")
+			return
+		}
+
+		switch field {
+		case "entrance":
+			order.Entrance = value
+		case "floor":
+			order.Floor = value
+		case "apartment":
+			order.Apartment = strconv.Itoa(value)
+		case "price":
+			order.Price = value
+		}
+		if err := b.db.UpdateOrder(*order, chatID); err != nil {
+			log.Printf("⚠️ Ошибка обновления заказа: %v", err)
+			b.sendMessage(chatID, "Не удалось сохранить изменения.")
+			return
+		}
+		delete(adminInputs, chatID)
+		b.sendOrderDetails(chatID, *order)
+
+	case StateAdminQuotePrice:
+		price, err := strconv.Atoi(strings.TrimSpace(msg.Text))
+		if err != nil || price <= 0 {
+			b.sendMessage(chatID, "Введите стоимость числом больше нуля:")
+			return
+		}
+		if order.Status != models.StatusAwaitingQuote {
+			delete(adminInputs, chatID)
+			b.sendMessage(chatID, fmt.Sprintf("Заявка №%d уже обработана: %s.", order.ID, i18n.T(i18n.Default, "status."+order.Status)))
+			return
+		}
+		order.Price = price
+		if err := b.db.UpdateOrder(*order, chatID); err != nil {
+			log.Printf("⚠️ Ошибка сохранения цены заявки: %v", err)
+			b.sendMessage(chatID, "Не удалось сохранить цену.")
+			return
+		}
+		delete(adminInputs, chatID)
+		b.sendQuoteOffer(*order)
+		b.sendMessage(chatID, fmt.Sprintf("Предложение на %d руб. по заявке №%d отправлено клиенту.", price, order.ID))
+
+	case StateAdminMessageCustomer:
+		text := b.t(order.UserID, "notify.admin_message", order.ID, msg.Text)
+		if _, err := b.api.Send(tgbotapi.NewMessage(order.UserID, text)); err != nil {
+			log.Printf("⚠️ Ошибка отправки сообщения клиенту: %v", err)
+			b.sendMessage(chatID, "Не удалось отправить сообщение клиенту.")
+			return
+		}
+		delete(adminInputs, chatID)
+		b.sendMessage(chatID, "Сообщение отправлено клиенту.")
+	}
+}
+
+// sendOrderDetails отправляет карточку заказа с кнопками действий
+func (b *Bot) sendOrderDetails(chatID int64, order models.Order) {
+	if !b.hasPermission(chatID, PermManageOrders) {
+		b.sendMessage(chatID, formatOrderDetails(order))
+		return
+	}
+
+	b.sendMessage(chatID, formatOrderDetails(order), orderActionsKeyboard(order))
+}
+
+// orderActionsKeyboard возвращает кнопки действий карточки заказа
+func orderActionsKeyboard(order models.Order) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if order.Status == models.StatusAwaitingQuote {
+		rows = append(rows, createQuotePriceKeyboard(order.ID).InlineKeyboard...)
+	}
+	if len(order.Photos) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Фото (%d)", len(order.Photos)), fmt.Sprintf("order_photos_%d", order.ID)),
+		))
+	}
+	// Статус заявки на расчет меняется только через назначение и принятие цены
+	if order.Status == models.StatusAwaitingQuote {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Изменить", fmt.Sprintf("order_edit_%d", order.ID)),
+		))
+	} else {
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Подтвердить", fmt.Sprintf("order_status_%d_confirmed", order.ID)),
+				tgbotapi.NewInlineKeyboardButtonData("На уточнение", fmt.Sprintf("order_status_%d_needs_clarification", order.ID)),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Изменить", fmt.Sprintf("order_edit_%d", order.ID)),
+				tgbotapi.NewInlineKeyboardButtonData("Отменить заказ", fmt.Sprintf("order_status_%d_canceled", order.ID)),
+			),
+		)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Написать клиенту", fmt.Sprintf("order_msg_%d", order.ID)),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// formatOrderShort возвращает однострочное описание заказа для списка
+func formatOrderShort(order models.Order) string {
+	return fmt.Sprintf("№%d · П%d, эт. %d, кв. %s · %d руб. · %s",
+		order.ID, order.Entrance, order.Floor, order.Apartment, order.Price, i18n.T(i18n.Default, "status."+order.Status))
+}
+
+// formatOrderDetails возвращает полное описание заказа для администратора
+func formatOrderDetails(order models.Order) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("Заказ №%d от %s\n", order.ID, order.CreatedAt.In(clock.Location()).Format("02.01.2006 15:04")))
+	text.WriteString(fmt.Sprintf("Статус: %s", i18n.T(i18n.Default, "status."+order.Status)))
+	if !order.IsCurrent {
+		text.WriteString(" (неактуальный)")
+	}
+	text.WriteString(fmt.Sprintf("\n\nПодъезд: %d\nЭтаж: %d\nКвартира: %s\n\nОкна:\n",
+		order.Entrance, order.Floor, order.Apartment))
+
+	for _, item := range order.Items {
+		text.WriteString(fmt.Sprintf("- %s: %d\n", itemTitleByCode(i18n.Default, item.ItemType), item.Quantity))
+	}
+	if len(order.Loggias) > 0 {
+		text.WriteString("\nЛоджии:\n")
+		for i, loggia := range order.Loggias {
+			text.WriteString(fmt.Sprintf("- %d: %s\n", i+1, loggiaTitle(i18n.Default, loggia)))
+		}
+	}
+	if len(order.Addons) > 0 {
+		text.WriteString("\nДоп. услуги:\n")
+		for _, addon := range order.Addons {
+			text.WriteString(fmt.Sprintf("- %s: %d\n", addonTitleByCode(i18n.Default, addon.AddonType), addon.Quantity))
+		}
+	}
+	if order.Description != "" {
+		text.WriteString("\nНестандартные окна:\n" + order.Description + "\n")
+		if len(order.Photos) > 0 {
+			text.WriteString(fmt.Sprintf("Фото: %d\n", len(order.Photos)))
+		}
+	}
+
+	if notes := accessNotes(i18n.Default, order); len(notes) > 0 {
+		text.WriteString("\nДоступ: " + strings.Join(notes, "; ") + "\n")
+	}
+	if order.Comment != "" {
+		text.WriteString("Комментарий: " + order.Comment + "\n")
+	}
+
+	text.WriteString(fmt.Sprintf("\nСтоимость: %d руб.\n\n", order.Price))
+	text.WriteString("Клиент: " + customerContact(order) + "\n")
+	if order.UserID != 0 && order.TelegramNick != "" {
+		text.WriteString("Ник из заказа: @" + strings.TrimPrefix(order.TelegramNick, "@") + "\n")
+	}
+	text.WriteString("Источник: " + i18n.T(i18n.Default, "source."+order.Source) + "\n")
+
+	return text.String()
+}
+
+func findUsage() string {
+	return "Использование:\n" +
+		"/find 123 — по номеру квартиры\n" +
+		"/find 3/12 — по подъезду и этажу\n" +
+		"/find @username — по нику\n" +
+		"/find #45 — по номеру заказа\n" +
+		"/find 123456789 — по Telegram ID\n" +
+		"Можно комбинировать: /find entrance=3 apt=123"
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+func TestParseFindQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    models.OrderSearch
+		wantErr bool
+	}{
+		{query: "123", want: models.OrderSearch{Apartment: "123"}},
+		{query: "1500", want: models.OrderSearch{Apartment: "1500"}},
+		{query: "1501", want: models.OrderSearch{TelegramID: 1501}},
+		{query: "123456789", want: models.OrderSearch{TelegramID: 123456789}},
+		{query: "#45", want: models.OrderSearch{OrderID: 45}},
+		{query: "@ivan_petrov", want: models.OrderSearch{UserName: "ivan_petrov"}},
+		{query: "3/12", want: models.OrderSearch{Entrance: 3, Floor: 12}},
+		{query: "id=7", want: models.OrderSearch{OrderID: 7}},
+		{query: "tg=42", want: models.OrderSearch{TelegramID: 42}},
+		{query: "user=@nick", want: models.OrderSearch{UserName: "nick"}},
+		{query: "Подъезд=2 этаж=5", want: models.OrderSearch{Entrance: 2, Floor: 5}},
+		{query: "entrance=4 apt=77", want: models.OrderSearch{Entrance: 4, Apartment: "77"}},
+		{query: "кв=10 3/2", want: models.OrderSearch{Apartment: "10", Entrance: 3, Floor: 2}},
+		{query: "3/x", wantErr: true},
+		{query: "abc", wantErr: true},
+		{query: "#abc", wantErr: true},
+		{query: "apt=12a", wantErr: true},
+		{query: "floor=верхний", wantErr: true},
+		{query: "color=red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := parseFindQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFindQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseFindQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderActionsKeyboard(t *testing.T) {
+	tests := []struct {
+		status     string
+		wantStatus bool
+		wantQuote  bool
+	}{
+		{status: "confirmed", wantStatus: true},
+		{status: "needs_clarification", wantStatus: true},
+		{status: "canceled", wantStatus: true},
+		{status: models.StatusAwaitingQuote, wantQuote: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			keyboard := orderActionsKeyboard(models.Order{ID: 7, Status: tt.status})
+			var hasStatus, hasQuote bool
+			for _, row := range keyboard.InlineKeyboard {
+				for _, button := range row {
+					switch {
+					case strings.HasPrefix(*button.CallbackData, "order_status_"):
+						hasStatus = true
+					case strings.HasPrefix(*button.CallbackData, "order_quote_"):
+						hasQuote = true
+					}
+				}
+			}
+			if hasStatus != tt.wantStatus || hasQuote != tt.wantQuote {
+				t.Errorf("status buttons = %v, quote button = %v, want %v, %v", hasStatus, hasQuote, tt.wantStatus, tt.wantQuote)
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package bot
 
 import (
-	"fmt"
+	"errors"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
 	"github.com/eugenepelipets/window-wash-bot/models"
 	"log"
 	"strconv"
@@ -18,42 +19,58 @@ const (
 	StateWindowsSameOrDifferent = "windows_same_or_different"
 	StateWindowsSameType        = "windows_same_type"
 	StateWindowsSameCount       = "windows_same_count"
-	StateWindowsDifferent3      = "windows_diff_3"
-	StateWindowsDifferent4      = "windows_diff_4"
-	StateWindowsDifferent5      = "windows_diff_5"
-	StateWindowsDifferent6_7    = "windows_diff_6_7"
+	StateWindowsDifferent       = "windows_different"
 	StateBalconyNeeded          = "balcony_needed"
 	StateBalconyType            = "balcony_type"
 	StateBalconySash            = "balcony_sash"
+	StateBalconyGlazing         = "balcony_glazing"
+	StateAddons                 = "addons"
 	StateTelegramNick           = "telegram_nick"
+	StateWaitingConfirmation    = "waiting_confirmation"
 )
 
-var userState = make(map[int64]string)
-var userOrders = make(map[int64]models.Order)
-
 func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 	chatID := callback.Message.Chat.ID
-	data := callback.Data
+	data, token := splitCallbackData(callback.Data)
+
+	if isOrderCallback(data) {
+		if notice := b.checkOrderCallback(chatID, callback.Message.MessageID, data, token); notice != "" {
+			b.answerCallback(callback.ID, notice)
+			return
+		}
+	}
 
 	switch {
 	case data == "new_order":
-		b.handleNewOrder(chatID)
+		b.handleNewOrder(chatID, callback.Message.MessageID)
+	case data == "repeat_order":
+		b.handleRepeatOrder(chatID, callback.Message.MessageID)
+	case strings.HasPrefix(data, "edit_"):
+		b.handleEditOrder(chatID, data)
 	case data == "back":
 		b.handleBack(chatID)
+	case strings.HasPrefix(data, "lang_"):
+		b.handleLanguageCallback(chatID, data)
 	case strings.HasPrefix(data, "entrance_"):
-		entrance, _ := strconv.Atoi(data[len("entrance_"):])
+		entrance, _ := strconv.Atoi(data[len("entrance_"):]) // значение проверено в checkOrderCallback
 		b.handleEntrance(chatID, entrance)
 	case data == "windows_same" || data == "windows_different":
 		b.handleWindowsSameOrDifferent(chatID, data == "windows_same")
-	case strings.HasPrefix(data, "window_"):
-		b.handleWindowTypeSelection(chatID, data)
+	case data == "windows_custom":
+		b.handleQuoteStart(chatID)
+	case data == "quote_send":
+		b.handleQuoteSend(chatID)
+	case strings.HasPrefix(data, "quote_accept_") || strings.HasPrefix(data, "quote_decline_"):
+		b.answerCallback(callback.ID, b.handleQuoteResponse(chatID, callback.Message.MessageID, data))
+		return
+	case strings.HasPrefix(data, "item_"):
+		b.handleWindowTypeSelection(chatID, data[len("item_"):])
+	case strings.HasPrefix(data, "count_step_"):
+		count, _ := strconv.Atoi(data[len("count_step_"):])
+		b.askWindowCount(chatID, createWindowStepperKeyboard(b.lang(chatID), count))
 	case strings.HasPrefix(data, "count_"):
 		count, _ := strconv.Atoi(data[len("count_"):])
-		if userSessions[chatID].Order.WindowsSame {
-			b.handleWindowSameCount(chatID, count)
-		} else {
-			b.handleWindowDifferentCount(chatID, count)
-		}
+		b.handleWindowCount(chatID, count)
 	case strings.HasPrefix(data, "balcony_"):
 		log.Printf("Обработка balcony callback: %s", data)
 		if count, err := strconv.Atoi(data[len("balcony_"):]); err == nil {
@@ -63,157 +80,323 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 		} else if strings.HasPrefix(data, "balcony_sash_") {
 			b.handleBalconySash(chatID, data[len("balcony_sash_"):])
 		}
+	case strings.HasPrefix(data, "glazing_"):
+		glazing := data[len("glazing_"):]
+		if glazing == "unknown" {
+			glazing = ""
+		}
+		b.handleBalconyGlazing(chatID, glazing)
+	case strings.HasPrefix(data, "addon_"):
+		b.handleAddonToggle(chatID, data[len("addon_"):])
+	case strings.HasPrefix(data, "notes_"):
+		b.handleNotesCallback(chatID, data)
+	case data == "addons_done":
+		b.continueOrder(chatID, func() { b.askTelegramNick(chatID) })
 	case data == "skip_nick":
 		b.handleTelegramNick(chatID, "")
+	case data == "use_nick":
+		username, _ := b.getSession(chatID).TempData["telegram_username"].(string)
+		b.handleTelegramNick(chatID, username)
 	case data == "confirm_order":
 		b.handleOrderConfirmation(chatID)
 	case data == "cancel_order":
 		b.handleOrderCancellation(chatID)
+	case strings.HasPrefix(data, "ex_"):
+		b.handleExportCallback(callback)
+	case strings.HasPrefix(data, "bc_"):
+		b.handleBroadcastCallback(chatID, data)
+	case strings.HasPrefix(data, "find_page_") || strings.HasPrefix(data, "order_"):
+		b.handleAdminOrderCallback(chatID, data)
 	default:
-		b.sendMessage(chatID, "Неизвестная команда")
+		b.sendMessage(chatID, b.t(chatID, "unknown_action"))
 	}
 
-	callbackConfig := tgbotapi.NewCallback(callback.ID, "")
-	if _, err := b.api.Request(callbackConfig); err != nil {
-		log.Printf("⚠️ Ошибка ответа на callback: %v", err)
-	}
+	b.answerCallback(callback.ID, "")
 }
 
-func (b *Bot) handleNewOrder(chatID int64) {
+// handleNewOrder начинает заказ; сообщение с нажатой кнопкой становится карточкой заказа
+func (b *Bot) handleNewOrder(chatID int64, messageID int) {
 	// Сбрасываем предыдущий заказ
-	userSessions[chatID] = &UserSession{
-		Order: models.Order{
-			UserID: chatID,
-		},
-	}
+	b.startOrderSession(chatID, models.Order{
+		UserID: chatID,
+		Source: models.OrderSourceBot,
+	}, messageID)
 	b.updateState(chatID, StateWaitingForEntrance)
-	b.sendMessage(chatID, "Выберите подъезд:", createEntranceKeyboard())
+	b.showStep(chatID, b.t(chatID, "order.choose_entrance"), createEntranceKeyboard(b.lang(chatID)))
 }
 
 func (b *Bot) handleEntrance(chatID int64, entrance int) {
 	session := b.getSession(chatID)
 	session.Order.Entrance = entrance
 	b.updateState(chatID, StateWaitingForFloor)
-	b.sendMessage(chatID, "Введите номер этажа (1-24):")
+	b.showStep(chatID, b.t(chatID, "order.enter_floor", maxFloor))
 }
 
+// Ключи TempData шага окон: выбранный тип в режиме «одинаковые» и номер позиции каталога,
+// о которой спрашивает режим «разные»
+const (
+	sameItemKey  = "same_item"
+	itemIndexKey = "item_index"
+)
+
 func (b *Bot) handleWindowsSameOrDifferent(chatID int64, isSame bool) {
 	session := b.getSession(chatID)
 	session.Order.WindowsSame = isSame
+	// Окна заполняются заново в выбранном режиме
+	session.Order.Items = nil
+	lang := b.lang(chatID)
 
 	if isSame {
 		b.updateState(chatID, StateWindowsSameType)
-		b.sendMessage(chatID, "Выберите количество створок на окнах:", createWindowTypesKeyboard())
+		b.showStep(chatID, i18n.T(lang, "order.choose_window_sash"), createWindowTypesKeyboard(lang))
 	} else {
-		b.updateState(chatID, StateWindowsDifferent3)
-		b.sendMessage(chatID, "Сколько 3-створчатых окон? (0-6)", createWindowCountKeyboard())
+		session.TempData[itemIndexKey] = 0
+		b.updateState(chatID, StateWindowsDifferent)
+		b.askWindowCount(chatID, createWindowCountKeyboard(lang))
 	}
 }
 
-func (b *Bot) handleWindowTypeSelection(chatID int64, sashType string) {
+func (b *Bot) handleWindowTypeSelection(chatID int64, code string) {
 	session := b.getSession(chatID)
-	session.Order.Window3Count = 0
-	session.Order.Window4Count = 0
-	session.Order.Window5Count = 0
-	session.Order.Window6_7Count = 0
-
-	switch sashType {
-	case "window_3":
-		session.Order.Window3Count = 1
-	case "window_4":
-		session.Order.Window4Count = 1
-	case "window_5":
-		session.Order.Window5Count = 1
-	case "window_6_7":
-		session.Order.Window6_7Count = 1
-	}
+	session.TempData[sameItemKey] = code
 
 	b.updateState(chatID, StateWindowsSameCount)
-	b.sendMessage(chatID, "Сколько всего окон?", createWindowCountKeyboard())
+	b.askWindowCount(chatID, createWindowCountKeyboard(b.lang(chatID)))
 }
 
-func (b *Bot) handleWindowSameCount(chatID int64, count int) {
-	session := b.getSession(chatID)
+// currentItem возвращает позицию каталога, о количестве которой спрашивает шаг окон
+func (s *UserSession) currentItem() (models.ItemType, bool) {
+	if s.CurrentState == StateWindowsSameCount {
+		code, _ := s.TempData[sameItemKey].(string)
+		return findItem(code)
+	}
+	items := activeItems()
+	index, _ := s.TempData[itemIndexKey].(int)
+	if index < 0 || index >= len(items) {
+		return models.ItemType{}, false
+	}
+	return items[index], true
+}
 
-	if session.Order.Window3Count > 0 {
-		session.Order.Window3Count = count
-	} else if session.Order.Window4Count > 0 {
-		session.Order.Window4Count = count
-	} else if session.Order.Window5Count > 0 {
-		session.Order.Window5Count = count
-	} else if session.Order.Window6_7Count > 0 {
-		session.Order.Window6_7Count = count
+// windowCountPrompt возвращает вопрос шага количества окон
+func windowCountPrompt(lang string, session *UserSession) string {
+	if session.CurrentState == StateWindowsDifferent {
+		if item, ok := session.currentItem(); ok {
+			return i18n.T(lang, "order.item_count", itemTitle(lang, item), maxWindowCount())
+		}
 	}
+	return i18n.T(lang, "order.windows_total", maxWindowCount())
+}
 
-	b.updateState(chatID, StateBalconyNeeded)
-	b.sendMessage(chatID, "Нужно ли мыть окна на лоджии?", createBalconyNeededKeyboard())
+// askWindowCount показывает вопрос о количестве окон для текущего шага с быстрыми кнопками или счетчиком
+func (b *Bot) askWindowCount(chatID int64, keyboard tgbotapi.InlineKeyboardMarkup) {
+	b.showStep(chatID, windowCountPrompt(b.lang(chatID), b.getSession(chatID)), keyboard)
 }
 
-func (b *Bot) handleWindowDifferentCount(chatID int64, count int) {
+// handleWindowCount принимает количество окон, выбранное кнопкой или введенное числом
+func (b *Bot) handleWindowCount(chatID int64, count int) {
 	session := b.getSession(chatID)
-
-	switch session.CurrentState {
-	case StateWindowsDifferent3:
-		session.Order.Window3Count = count
-		b.updateState(chatID, StateWindowsDifferent4)
-		b.sendMessage(chatID, "Сколько 4-створчатых окон? (0-6)", createWindowCountKeyboard())
-
-	case StateWindowsDifferent4:
-		session.Order.Window4Count = count
-		b.updateState(chatID, StateWindowsDifferent5)
-		b.sendMessage(chatID, "Сколько 5-створчатых окон? (0-6)", createWindowCountKeyboard())
-
-	case StateWindowsDifferent5:
-		session.Order.Window5Count = count
-		b.updateState(chatID, StateWindowsDifferent6_7)
-		b.sendMessage(chatID, "Сколько 6-7-створчатых окон? (0-6)", createWindowCountKeyboard())
-
-	case StateWindowsDifferent6_7:
-		session.Order.Window6_7Count = count
-		b.updateState(chatID, StateBalconyNeeded)
-		b.sendMessage(chatID, "Нужно ли мыть окна на лоджии?", createBalconyNeededKeyboard())
+	item, ok := session.currentItem()
+	if !ok {
+		b.sendMessage(chatID, b.t(chatID, "unknown_action"))
+		return
 	}
+	session.Order.SetQuantity(item.Code, count)
+
+	if session.CurrentState == StateWindowsDifferent {
+		// Следующий тип окон из каталога
+		index, _ := session.TempData[itemIndexKey].(int)
+		if index+1 < len(activeItems()) {
+			session.TempData[itemIndexKey] = index + 1
+			b.updateState(chatID, StateWindowsDifferent)
+			b.askWindowCount(chatID, createWindowCountKeyboard(b.lang(chatID)))
+			return
+		}
+	}
+
+	b.continueOrder(chatID, func() { b.askBalconyNeeded(chatID) })
 }
 
+// askBalconyNeeded переходит к вопросу о лоджиях
+func (b *Bot) askBalconyNeeded(chatID int64) {
+	b.updateState(chatID, StateBalconyNeeded)
+	b.showStep(chatID, b.t(chatID, "order.balcony_needed"), createBalconyNeededKeyboard(b.lang(chatID)))
+}
+
+// loggiaCountKey — сколько лоджий выбрал клиент; вопросы задаются по каждой лоджии по очереди
+const loggiaCountKey = "loggia_count"
+
 func (b *Bot) handleBalconyNeeded(chatID int64, count int) {
 	session := b.getSession(chatID)
-	session.Order.BalconyCount = count
 
 	if count > 0 {
-		// Сбрасываем предыдущие значения
-		session.Order.BalconyType = ""
-		session.Order.BalconySash = ""
+		// Лоджии заполняются заново, по одной за круг вопросов
+		session.Order.Loggias = nil
+		session.TempData[loggiaCountKey] = count
+		b.startLoggia(chatID)
+		return
+	}
 
-		b.updateState(chatID, StateBalconyType)
-		b.sendMessage(chatID, "Окна на лоджии стандартные или до пола?", createBalconyTypeKeyboard())
-	} else {
-		// Если лоджии не нужны, сразу переходим к нику
-		b.updateState(chatID, StateTelegramNick)
-		b.sendMessage(chatID, "Введите ваш ник в Telegram (или нажмите 'Пропустить'):", createSkipKeyboard())
+	// Без окон и лоджий заказывать нечего — остаемся на этом шаге
+	if session.Order.ItemCount() == 0 {
+		lang := b.lang(chatID)
+		b.showStep(chatID, i18n.T(lang, "order.empty")+"\n\n"+i18n.T(lang, "order.balcony_needed"),
+			createBalconyNeededKeyboard(lang))
+		return
+	}
+
+	// Если лоджии не нужны, сразу переходим к дополнительным услугам
+	session.Order.Loggias = nil
+	b.continueOrder(chatID, func() { b.askAddons(chatID) })
+}
+
+// startLoggia добавляет в заказ следующую лоджию и спрашивает, какие на ней окна
+func (b *Bot) startLoggia(chatID int64) {
+	session := b.getSession(chatID)
+	session.Order.Loggias = append(session.Order.Loggias, models.Loggia{})
+	b.updateState(chatID, StateBalconyType)
+	b.askLoggia(chatID, "order.balcony_type", createBalconyTypeKeyboard(b.lang(chatID)))
+}
+
+// askLoggia задает вопрос о текущей лоджии; если лоджий несколько, добавляет ее номер
+func (b *Bot) askLoggia(chatID int64, key string, keyboard tgbotapi.InlineKeyboardMarkup) {
+	session := b.getSession(chatID)
+	lang := b.lang(chatID)
+	prompt := i18n.T(lang, key)
+	if total, _ := session.TempData[loggiaCountKey].(int); total > 1 {
+		prompt = i18n.T(lang, "order.loggia_number", len(session.Order.Loggias), total) + "\n" + prompt
+	}
+	b.showStep(chatID, prompt, keyboard)
+}
+
+// currentLoggia возвращает лоджию, о которой сейчас спрашивает диалог
+func (s *UserSession) currentLoggia() *models.Loggia {
+	if len(s.Order.Loggias) == 0 {
+		s.Order.Loggias = append(s.Order.Loggias, models.Loggia{})
 	}
+	return &s.Order.Loggias[len(s.Order.Loggias)-1]
 }
 
 func (b *Bot) handleBalconyType(chatID int64, balconyType string) {
 	session := b.getSession(chatID)
-	session.Order.BalconyType = balconyType
+	session.currentLoggia().Type = balconyType
 	b.updateState(chatID, StateBalconySash)
-	b.sendMessage(chatID, "Выберите количество створок на лоджии:", createBalconySashKeyboard())
+	b.askLoggia(chatID, "order.balcony_sash", createBalconySashKeyboard(b.lang(chatID)))
 }
 
 func (b *Bot) handleBalconySash(chatID int64, sashType string) {
 	session := b.getSession(chatID)
-	session.Order.BalconySash = sashType
+	session.currentLoggia().Sash = sashType
+	b.updateState(chatID, StateBalconyGlazing)
+	b.askLoggia(chatID, "order.balcony_glazing", createBalconyGlazingKeyboard(b.lang(chatID)))
+}
+
+// handleBalconyGlazing сохраняет остекление лоджии (пусто — клиент не знает)
+// и переходит к следующей лоджии или к дополнительным услугам
+func (b *Bot) handleBalconyGlazing(chatID int64, glazing string) {
+	session := b.getSession(chatID)
+	session.currentLoggia().Glazing = glazing
+
+	if total, _ := session.TempData[loggiaCountKey].(int); len(session.Order.Loggias) < total {
+		b.startLoggia(chatID)
+		return
+	}
+	b.continueOrder(chatID, func() { b.askAddons(chatID) })
+}
+
+// askAddons переходит к выбору дополнительных услуг; если к заказу применимых услуг нет,
+// шаг пропускается
+func (b *Bot) askAddons(chatID int64) {
+	if len(availableAddons(b.getSession(chatID).Order)) == 0 {
+		b.continueOrder(chatID, func() { b.askTelegramNick(chatID) })
+		return
+	}
+	b.updateState(chatID, StateAddons)
+	b.showAddonsStep(chatID)
+}
+
+// showAddonsStep показывает услуги с отметками выбранных; нажатие на услугу включает или выключает ее
+func (b *Bot) showAddonsStep(chatID int64) {
+	session := b.getSession(chatID)
+	lang := b.lang(chatID)
+	b.showStep(chatID, i18n.T(lang, "order.addons"),
+		createAddonsKeyboard(lang, availableAddons(session.Order), session.Order))
+}
+
+func (b *Bot) handleAddonToggle(chatID int64, code string) {
+	session := b.getSession(chatID)
+	if session.CurrentState != StateAddons {
+		b.sendMessage(chatID, b.t(chatID, "unknown_action"))
+		return
+	}
+	session.Order.ToggleAddon(code)
+	b.showAddonsStep(chatID)
+}
+
+// askTelegramNick переходит к шагу ника; в заказах по телефону ника нет, и шаг пропускается
+func (b *Bot) askTelegramNick(chatID int64) {
+	session := b.getSession(chatID)
+	if session.Order.Source == models.OrderSourcePhone {
+		b.handleTelegramNick(chatID, "")
+		return
+	}
+
 	b.updateState(chatID, StateTelegramNick)
-	b.sendMessage(chatID, "Введите ваш ник в Telegram (или нажмите 'Пропустить'):", createSkipKeyboard())
+	b.sendNickRequest(chatID)
+}
+
+// sendNickRequest предлагает ник из профиля Telegram одной кнопкой или ввод вручную
+func (b *Bot) sendNickRequest(chatID int64) {
+	username := ""
+	user, err := b.db.GetUser(chatID)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	} else if user != nil {
+		if nick, ok := NormalizeTelegramNick(user.UserName); ok {
+			username = nick
+		}
+	}
+	b.getSession(chatID).TempData["telegram_username"] = username
+
+	lang := b.lang(chatID)
+	if username == "" {
+		b.showStep(chatID, i18n.T(lang, "order.nick_enter"), createNickKeyboard(lang, ""))
+		return
+	}
+	b.showStep(chatID, i18n.T(lang, "order.nick_profile", username), createNickKeyboard(lang, username))
+}
+
+// handleTelegramNickInput проверяет ник, введенный вручную
+func (b *Bot) handleTelegramNickInput(chatID int64, text string) {
+	nick, ok := NormalizeTelegramNick(text)
+	if !ok {
+		username, _ := b.getSession(chatID).TempData["telegram_username"].(string)
+		b.showStep(chatID, b.t(chatID, "order.nick_invalid"), createNickKeyboard(b.lang(chatID), username))
+		return
+	}
+	b.handleTelegramNick(chatID, nick)
 }
 
 func (b *Bot) handleTelegramNick(chatID int64, nick string) {
 	session := b.getSession(chatID)
 	session.Order.TelegramNick = nick
+	b.continueOrder(chatID, func() { b.askContactPhone(chatID) })
+}
 
-	price, err := CalculatePrice(session.Order)
+// showPricedConfirmation рассчитывает стоимость и показывает заказ на подтверждение
+func (b *Bot) showPricedConfirmation(chatID int64) {
+	session := b.getSession(chatID)
+	price, err := CalculatePrice(&session.Order)
+	if errors.Is(err, errEmptyOrder) {
+		// Окна и лоджии убрали при изменении заказа — просим выбрать окна заново
+		lang := b.lang(chatID)
+		b.updateState(chatID, StateWindowsSameOrDifferent)
+		b.showStep(chatID, i18n.T(lang, "order.empty")+"\n\n"+i18n.T(lang, "order.windows_same_or_different"),
+			b.windowsModeKeyboard(chatID))
+		return
+	}
 	if err != nil {
-		b.sendMessage(chatID, "Ошибка расчета стоимости. Пожалуйста, начните заново.")
+		b.showStep(chatID, b.t(chatID, "order.price_error"))
 		return
 	}
 	session.Order.Price = price
@@ -222,146 +405,122 @@ func (b *Bot) handleTelegramNick(chatID int64, nick string) {
 }
 
 func (b *Bot) showOrderConfirmation(chatID int64, order models.Order) {
-	// Рассчитываем стоимость для каждого типа
-	var window3Sum, window4Sum, window5Sum, window6_7Sum, balconySum int
+	lang := b.lang(chatID)
+	details := orderDetails(lang, order)
+
+	b.updateState(chatID, StateWaitingConfirmation)
+	b.showCard(chatID, i18n.T(lang, "confirm.title")+"\n\n"+details, createConfirmationKeyboard(lang, order.Source == models.OrderSourcePhone, len(availableAddons(order)) > 0))
+}
+
+// orderDetails описывает заказ с расчетом стоимости для подтверждения и итоговой карточки
+func orderDetails(lang string, order models.Order) string {
 	var details strings.Builder
+	total := 0
 
 	// Добавляем основную информацию
-	details.WriteString(fmt.Sprintf("Подтвердите заказ:\n\nПодъезд: %d\nЭтаж: %d\nКвартира: %s\n\nОкна:\n",
-		order.Entrance, order.Floor, order.Apartment))
-
-	// Расчёт стоимости окон
-	if order.Window3Count > 0 {
-		window3Sum = order.Window3Count * 1000
-		details.WriteString(fmt.Sprintf("- 3-створчатые: %d * 1000 = %d руб.\n", order.Window3Count, window3Sum))
-	}
-	if order.Window4Count > 0 {
-		window4Sum = order.Window4Count * 1500
-		details.WriteString(fmt.Sprintf("- 4-створчатые: %d * 1500 = %d руб.\n", order.Window4Count, window4Sum))
-	}
-	if order.Window5Count > 0 {
-		window5Sum = order.Window5Count * 2000
-		details.WriteString(fmt.Sprintf("- 5-створчатые: %d * 2000 = %d руб.\n", order.Window5Count, window5Sum))
+	if order.Source == models.OrderSourcePhone {
+		details.WriteString(i18n.T(lang, "confirm.phone_customer", order.CustomerName, order.CustomerPhone) + "\n\n")
+	} else if order.TelegramNick != "" || order.CustomerPhone != "" {
+		if order.TelegramNick != "" {
+			details.WriteString(i18n.T(lang, "confirm.nick", order.TelegramNick) + "\n")
+		}
+		if order.CustomerPhone != "" {
+			details.WriteString(i18n.T(lang, "confirm.contact_phone", order.CustomerPhone) + "\n")
+		}
+		details.WriteString("\n")
 	}
-	if order.Window6_7Count > 0 {
-		window6_7Sum = order.Window6_7Count * 2500
-		details.WriteString(fmt.Sprintf("- 6-7-створчатые: %d * 2500 = %d руб.\n", order.Window6_7Count, window6_7Sum))
+	details.WriteString(i18n.T(lang, "confirm.address", order.Entrance, order.Floor, order.Apartment) + "\n\n")
+	details.WriteString(i18n.T(lang, "confirm.windows") + "\n")
+
+	// Расчёт стоимости окон по ценам позиций
+	for _, item := range order.Items {
+		total += item.Quantity * item.UnitPrice
+		details.WriteString(i18n.T(lang, "confirm.item_line", itemTitleByCode(lang, item.ItemType),
+			item.Quantity, item.UnitPrice, item.Quantity*item.UnitPrice) + "\n")
 	}
 
-	// Расчёт стоимости лоджий
-	if order.BalconyCount > 0 {
-		details.WriteString("\nЛоджии:\n")
-		var balconyPrice int
-		switch order.BalconySash {
-		case "3":
-			if order.BalconyType == "standard" {
-				balconyPrice = 1000
-			} else {
-				balconyPrice = 1500
-			}
-		case "4":
-			if order.BalconyType == "standard" {
-				balconyPrice = 1500
-			} else {
-				balconyPrice = 2000
-			}
-		case "5":
-			if order.BalconyType == "standard" {
-				balconyPrice = 2000
-			} else {
-				balconyPrice = 2500
-			}
-		case "6_7":
-			if order.BalconyType == "standard" {
-				balconyPrice = 2500
-			} else {
-				balconyPrice = 3000
-			}
+	// Расчёт стоимости лоджий: каждая по своей цене
+	if len(order.Loggias) > 0 {
+		details.WriteString("\n" + i18n.T(lang, "confirm.loggias") + "\n")
+		for i, loggia := range order.Loggias {
+			price := LoggiaPrice(loggia)
+			total += price
+			details.WriteString(i18n.T(lang, "confirm.loggia_line", i+1, loggiaTitle(lang, loggia), price) + "\n")
 		}
+	}
 
-		balconySum = order.BalconyCount * balconyPrice
-		balconyType := "стандартные"
-		if order.BalconyType == "floor" {
-			balconyType = "до пола"
+	// Дополнительные услуги
+	if len(order.Addons) > 0 {
+		details.WriteString("\n" + i18n.T(lang, "confirm.addons") + "\n")
+		for _, addon := range order.Addons {
+			total += addon.Quantity * addon.UnitPrice
+			details.WriteString(i18n.T(lang, "confirm.addon_line", addonTitleByCode(lang, addon.AddonType),
+				addon.Quantity, addon.UnitPrice, addon.Quantity*addon.UnitPrice) + "\n")
 		}
+	}
 
-		details.WriteString(fmt.Sprintf("- %d лоджии (%s, %s створки): %d * %d = %d руб.\n",
-			order.BalconyCount, balconyType, order.BalconySash,
-			order.BalconyCount, balconyPrice, balconySum))
+	// Пожелания и условия доступа
+	notes := accessNotes(lang, order)
+	if order.Comment != "" {
+		notes = append(notes, i18n.T(lang, "notes.comment", order.Comment))
+	}
+	if len(notes) > 0 {
+		details.WriteString("\n" + i18n.T(lang, "confirm.notes") + "\n")
+		for _, note := range notes {
+			details.WriteString("- " + note + "\n")
+		}
 	}
 
 	// Итоговая стоимость
-	total := window3Sum + window4Sum + window5Sum + window6_7Sum + balconySum
-	details.WriteString(fmt.Sprintf("\nИтого стоимость: %d руб.", total))
-
-	b.updateState(chatID, "waiting_confirmation")
-	b.sendMessage(chatID, details.String(), createConfirmationKeyboard())
+	details.WriteString("\n" + i18n.T(lang, "confirm.total", total))
+	return details.String()
 }
 
 func (b *Bot) handleOrderConfirmation(chatID int64) {
 	session := b.getSession(chatID)
+	if _, err := CalculatePrice(&session.Order); err != nil {
+		b.showPricedConfirmation(chatID)
+		return
+	}
 	order := session.Order
 
 	// Проверяем существующие заказы перед сохранением
 	exists, err := b.db.CheckExistingOrder(order.Entrance, order.Floor, order.Apartment)
 	if err != nil {
-		b.sendMessage(chatID, "Ошибка проверки заказов. Попробуйте позже.")
+		b.sendMessage(chatID, b.t(chatID, "order.check_error"))
 		return
 	}
 
+	isPhoneOrder := order.Source == models.OrderSourcePhone
+	var result string
 	if exists {
 		order.Status = "needs_clarification"
 		b.notifyAdminAboutDuplicate(chatID, order)
-		b.sendMessage(chatID,
-			"Похоже, кто-то уже создал заявку для этой квартиры.\n"+
-				"Ваш заказ поставлен на уточнение. Администратор свяжется с вами.")
+		if isPhoneOrder {
+			result = b.t(chatID, "phone_order.duplicate")
+		} else {
+			result = b.t(chatID, "order.duplicate")
+		}
 	} else {
 		order.Status = "confirmed"
-		b.sendMessage(chatID, "Ваш заказ подтвержден! Ожидайте мастера.")
+		if isPhoneOrder {
+			result = b.t(chatID, "phone_order.confirmed")
+		} else {
+			result = b.t(chatID, "order.confirmed")
+		}
 	}
 
 	if err := b.db.SaveOrder(order); err != nil {
-		b.sendMessage(chatID, "Ошибка сохранения заказа. Пожалуйста, попробуйте позже.")
+		b.sendMessage(chatID, b.t(chatID, "order.save_error"))
 		return
 	}
 
+	// Итог оформления остается в карточке заказа, кнопки с нее убираются
+	b.showCard(chatID, result+"\n\n"+orderDetails(b.lang(chatID), order))
 	delete(userSessions, chatID)
-	delete(userState, chatID)
 }
 
 func (b *Bot) handleOrderCancellation(chatID int64) {
+	b.showCard(chatID, b.t(chatID, "order.canceled"), createMainMenuKeyboard(b.lang(chatID)))
 	delete(userSessions, chatID)
-	delete(userState, chatID)
-	b.sendMessage(chatID, "Заказ отменен.", createMainMenuKeyboard())
-}
-
-func (b *Bot) validateFloor(msg *tgbotapi.Message) {
-	floor, err := strconv.Atoi(msg.Text)
-	if err != nil || floor < 1 || floor > 24 {
-		b.sendMessage(msg.Chat.ID, "Некорректный этаж. Введите цифру от 1 до 24:")
-		return
-	}
-
-	session := b.getSession(msg.Chat.ID)
-	session.Order.Floor = floor
-	b.updateState(msg.Chat.ID, StateWaitingForApartment)
-	b.sendMessage(msg.Chat.ID, "Введите номер квартиры (1-1500):")
-}
-
-func (b *Bot) validateApartment(msg *tgbotapi.Message) {
-	if !IsDigitsOnly(msg.Text) {
-		b.sendMessage(msg.Chat.ID, "Некорректный номер квартиры. Введите только цифры:")
-		return
-	}
-
-	apartment, _ := strconv.Atoi(msg.Text)
-	if apartment < 1 || apartment > 1500 {
-		b.sendMessage(msg.Chat.ID, "Некорректный номер квартиры. Введите цифру от 1 до 1500:")
-		return
-	}
-
-	session := b.getSession(msg.Chat.ID)
-	session.Order.Apartment = msg.Text
-	b.updateState(msg.Chat.ID, StateWindowsSameOrDifferent)
-	b.sendMessage(msg.Chat.ID, "Количество створок на окнах одинаковое или разное?", createWindowsSameOrDifferentKeyboard())
 }
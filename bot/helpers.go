@@ -1,51 +1,89 @@
 package bot
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/eugenepelipets/window-wash-bot/models"
 )
 
-// Расчет цены на основе типа окон и этажа
-func CalculatePrice(order models.Order) (int, error) {
+// Ограничения, которые проверяются при оформлении и загрузке заказов
+const (
+	maxEntrance     = 6
+	maxFloor        = 24
+	maxApartment    = 1500
+	maxBalconyCount = 3
+
+	quickWindowCount      = 6  // количество окон, которое можно выбрать одной кнопкой
+	defaultMaxWindowCount = 30 // окон одного типа, если MAX_WINDOW_COUNT не задан
+)
+
+// errEmptyOrder — в заказе не выбрано ни одного окна и ни одной лоджии
+var errEmptyOrder = errors.New("не указано ни одного окна или лоджии")
+
+// maxWindowCount возвращает наибольшее количество окон одного типа в заказе.
+// Значение задается переменной окружения MAX_WINDOW_COUNT.
+func maxWindowCount() int {
+	if n, err := strconv.Atoi(os.Getenv("MAX_WINDOW_COUNT")); err == nil && n >= quickWindowCount {
+		return n
+	}
+	return defaultMaxWindowCount
+}
+
+// LoggiaPrice возвращает цену мойки одной лоджии; окна до пола дороже на 500 руб.
+func LoggiaPrice(loggia models.Loggia) int {
+	price := sashPrice(loggia.Sash)
+	if price > 0 && loggia.Type != models.LoggiaStandard {
+		price += 500
+	}
+	return price
+}
+
+// CalculatePrice рассчитывает стоимость заказа по текущему каталогу и проставляет
+// позициям цену за штуку
+func CalculatePrice(order *models.Order) (int, error) {
+	if order.ItemCount() == 0 && len(order.Loggias) == 0 {
+		return 0, errEmptyOrder
+	}
+
 	total := 0
 
-	// Окна
-	total += order.Window3Count * 1000
-	total += order.Window4Count * 1500
-	total += order.Window5Count * 2000
-	total += order.Window6_7Count * 2500
-
-	// Лоджии
-	if order.BalconyCount > 0 {
-		var balconyPrice int
-		switch order.BalconySash {
-		case "3":
-			if order.BalconyType == "standard" {
-				balconyPrice = 1000
-			} else {
-				balconyPrice = 1500
-			}
-		case "4":
-			if order.BalconyType == "standard" {
-				balconyPrice = 1500
-			} else {
-				balconyPrice = 2000
-			}
-		case "5":
-			if order.BalconyType == "standard" {
-				balconyPrice = 2000
-			} else {
-				balconyPrice = 2500
-			}
-		case "6_7":
-			if order.BalconyType == "standard" {
-				balconyPrice = 2500
-			} else {
-				balconyPrice = 3000
-			}
+	// Окна и другие изделия
+	for i, item := range order.Items {
+		itemType, ok := findItem(item.ItemType)
+		if !ok {
+			return 0, fmt.Errorf("неизвестный тип изделия «%s»", item.ItemType)
 		}
-		total += balconyPrice * order.BalconyCount
+		order.Items[i].UnitPrice = itemType.Price
+		total += item.Quantity * itemType.Price
+	}
+
+	// Лоджии считаются по отдельности
+	for _, loggia := range order.Loggias {
+		total += LoggiaPrice(loggia)
 	}
 
+	// Дополнительные услуги: количество зависит от окон и лоджий, поэтому пересчитывается;
+	// услуги, которые после изменения заказа стали неприменимы, убираются
+	var addons []models.OrderAddon
+	for _, addon := range order.Addons {
+		addonType, ok := findAddon(addon.AddonType)
+		if !ok {
+			return 0, fmt.Errorf("неизвестная дополнительная услуга «%s»", addon.AddonType)
+		}
+		addon.Quantity = addonQuantity(addonType, *order)
+		if addon.Quantity == 0 {
+			continue
+		}
+		addon.UnitPrice = addonType.Price
+		total += addon.Quantity * addon.UnitPrice
+		addons = append(addons, addon)
+	}
+	order.Addons = addons
+
 	return total, nil
 }
 
@@ -61,3 +99,96 @@ func IsDigitsOnly(s string) bool {
 	}
 	return true
 }
+
+// orderStatuses — допустимые коды статусов заказа; названия берутся из каталога i18n ("status.<код>")
+var orderStatuses = map[string]bool{
+	"pending":                  true,
+	"confirmed":                true,
+	"needs_clarification":      true,
+	models.StatusAwaitingQuote: true,
+	"canceled":                 true,
+}
+
+// NormalizePhone приводит номер телефона к формату E.164 (+79001234567).
+// Российские номера, начинающиеся с 8 или записанные без кода страны, переводятся в +7.
+func NormalizePhone(s string) (string, bool) {
+	var digits strings.Builder
+	hasPlus := false
+	for i, c := range strings.TrimSpace(s) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && i == 0:
+			hasPlus = true
+		case c == ' ' || c == '-' || c == '(' || c == ')':
+		default:
+			return "", false
+		}
+	}
+
+	number := digits.String()
+	if !hasPlus && len(number) == 11 && number[0] == '8' {
+		number = "7" + number[1:]
+	}
+	// Российский номер без кода страны не начинается с нуля
+	if !hasPlus && len(number) == 10 && number[0] != '0' {
+		number = "7" + number
+	}
+	if len(number) < 10 || len(number) > 15 || number[0] == '0' {
+		return "", false
+	}
+	return "+" + number, true
+}
+
+// NormalizeTelegramNick приводит ник к виду без "@" и проверяет правила Telegram:
+// 5–32 символа, латинские буквы, цифры и "_", начинается с буквы и не заканчивается на "_".
+// Принимаются также ссылки вида t.me/username.
+func NormalizeTelegramNick(s string) (string, bool) {
+	nick := strings.TrimSpace(s)
+	for _, prefix := range []string{"https://", "http://", "t.me/", "telegram.me/", "@"} {
+		nick = strings.TrimPrefix(nick, prefix)
+	}
+
+	if len(nick) < 5 || len(nick) > 32 || strings.HasSuffix(nick, "_") {
+		return "", false
+	}
+	for i, c := range nick {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		switch {
+		case i == 0 && !isLetter:
+			return "", false
+		case !isLetter && !(c >= '0' && c <= '9') && c != '_':
+			return "", false
+		}
+	}
+	return nick, true
+}
+
+// TelegramLink возвращает ссылку на пользователя: по нику, а если его нет — по Telegram ID
+func TelegramLink(userID int64, username string) string {
+	if username != "" {
+		return "https://t.me/" + username
+	}
+	return "tg://user?id=" + formatID(userID)
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// splitMessage делит длинный текст на части не длиннее limit символов по границам строк
+func splitMessage(text string, limit int) []string {
+	var parts []string
+	var current strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if current.Len() > 0 && len([]rune(current.String()))+len([]rune(line)) > limit {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
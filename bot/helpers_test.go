@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"+79001234567", "+79001234567", true},
+		{"89001234567", "+79001234567", true},
+		{"9001234567", "+79001234567", true},
+		{"8 (900) 123-45-67", "+79001234567", true},
+		{"  +7 900 123 45 67  ", "+79001234567", true},
+		{"+90 532 123 45 67", "+905321234567", true},
+		{"+44 20 7946 0958", "+442079460958", true},
+		{"+8 900 123 45 67", "+89001234567", true},
+		{"12345", "", false},
+		{"+1234567890123456", "", false},
+		{"0123456789", "", false},
+		{"+7 900 123 45 67 доб. 1", "", false},
+		{"7+9001234567", "", false},
+		{"+7.900.123.45.67", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizePhone(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizePhone(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNormalizeTelegramNick(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"ivan_petrov", "ivan_petrov", true},
+		{"@ivan_petrov", "ivan_petrov", true},
+		{"  @Ivan2000 ", "Ivan2000", true},
+		{"t.me/ivan_petrov", "ivan_petrov", true},
+		{"https://t.me/ivan_petrov", "ivan_petrov", true},
+		{"http://telegram.me/ivan_petrov", "ivan_petrov", true},
+		{"abcde", "abcde", true},
+		{"a2345678901234567890123456789012", "a2345678901234567890123456789012", true},
+		{"abcd", "", false},
+		{"a23456789012345678901234567890123", "", false},
+		{"1ivan", "", false},
+		{"_ivan", "", false},
+		{"ivan_", "", false},
+		{"ivan-petrov", "", false},
+		{"иван_петров", "", false},
+		{"ivan petrov", "", false},
+		{"@", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeTelegramNick(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeTelegramNick(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCalculatePrice(t *testing.T) {
+	useTestCatalog(t)
+
+	tests := []struct {
+		name       string
+		order      models.Order
+		want       int
+		wantAddons []models.OrderAddon
+		wantErr    bool
+		wantEmpty  bool // ошибка errEmptyOrder
+	}{
+		{
+			name:      "пустой заказ",
+			order:     models.Order{},
+			wantErr:   true,
+			wantEmpty: true,
+		},
+		{
+			name:      "позиции с нулевым количеством не считаются окнами",
+			order:     models.Order{Items: []models.OrderItem{{ItemType: "window_3", Quantity: 0}}},
+			wantErr:   true,
+			wantEmpty: true,
+		},
+		{
+			name: "окна разных типов",
+			order: models.Order{Items: []models.OrderItem{
+				{ItemType: "window_3", Quantity: 2},
+				{ItemType: "window_6_7", Quantity: 1},
+				{ItemType: "door", Quantity: 1},
+			}},
+			want: 2*1000 + 2500 + 700,
+		},
+		{
+			name:  "только лоджии",
+			order: models.Order{Loggias: []models.Loggia{{Type: models.LoggiaStandard, Sash: "4"}}},
+			want:  1500,
+		},
+		{
+			name: "услуги пересчитываются по окнам и лоджиям",
+			order: models.Order{
+				Items:   []models.OrderItem{{ItemType: "window_4", Quantity: 3}},
+				Loggias: []models.Loggia{{Type: models.LoggiaFloor, Sash: "5"}},
+				Addons: []models.OrderAddon{
+					{AddonType: "frames", Quantity: 1},
+					{AddonType: "sills"},
+					{AddonType: "trip"},
+				},
+			},
+			want: 3*1500 + 2000 + 500 + 4*300 + 3*200 + 500,
+			wantAddons: []models.OrderAddon{
+				{AddonType: "frames", Quantity: 4, UnitPrice: 300},
+				{AddonType: "sills", Quantity: 3, UnitPrice: 200},
+				{AddonType: "trip", Quantity: 1, UnitPrice: 500},
+			},
+		},
+		{
+			name: "неприменимая услуга убирается",
+			order: models.Order{
+				Loggias: []models.Loggia{{Type: models.LoggiaStandard, Sash: "3"}},
+				Addons:  []models.OrderAddon{{AddonType: "sills"}},
+			},
+			want: 1000,
+		},
+		{
+			name:    "неизвестное изделие",
+			order:   models.Order{Items: []models.OrderItem{{ItemType: "skylight", Quantity: 1}}},
+			wantErr: true,
+		},
+		{
+			name: "неизвестная услуга",
+			order: models.Order{
+				Items:  []models.OrderItem{{ItemType: "window_3", Quantity: 1}},
+				Addons: []models.OrderAddon{{AddonType: "polish"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := tt.order
+			got, err := CalculatePrice(&order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CalculatePrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantEmpty && !errors.Is(err, errEmptyOrder) {
+				t.Fatalf("CalculatePrice() error = %v, want %v", err, errEmptyOrder)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("CalculatePrice() = %d, want %d", got, tt.want)
+			}
+			if !reflect.DeepEqual(order.Addons, tt.wantAddons) {
+				t.Errorf("addons = %+v, want %+v", order.Addons, tt.wantAddons)
+			}
+			for _, item := range order.Items {
+				if catalogItem, _ := findItem(item.ItemType); item.UnitPrice != catalogItem.Price {
+					t.Errorf("%s: unit price = %d, want %d", item.ItemType, item.UnitPrice, catalogItem.Price)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggiaPrice(t *testing.T) {
+	useTestCatalog(t)
+
+	tests := []struct {
+		name   string
+		loggia models.Loggia
+		want   int
+	}{
+		{"стандартные окна", models.Loggia{Type: models.LoggiaStandard, Sash: "3"}, 1000},
+		{"окна до пола дороже", models.Loggia{Type: models.LoggiaFloor, Sash: "3"}, 1500},
+		{"остекление не влияет на цену", models.Loggia{Type: models.LoggiaStandard, Sash: "6_7", Glazing: models.GlazingWarm}, 2500},
+		{"створки выведенного из продажи окна", models.Loggia{Type: models.LoggiaFloor, Sash: "2"}, 1300},
+		{"створок нет в каталоге", models.Loggia{Type: models.LoggiaFloor, Sash: "9"}, 0},
+		{"створки не указаны", models.Loggia{Type: models.LoggiaStandard}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoggiaPrice(tt.loggia); got != tt.want {
+				t.Errorf("LoggiaPrice(%+v) = %d, want %d", tt.loggia, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,683 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/storage"
+	"github.com/eugenepelipets/window-wash-bot/xlsx"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// StateAdminImport — администратор должен прислать файл с заказами
+const StateAdminImport = "admin_import"
+
+// maxImportFileSize — ограничение размера загружаемого файла
+const maxImportFileSize = 10 << 20
+
+// Колонки файла импорта и их допустимые названия в заголовке.
+// Названия совпадают с заголовками выгрузки, поэтому выгруженный файл можно загрузить обратно.
+const (
+	colEntrance     = "entrance"
+	colFloor        = "floor"
+	colApartment    = "apartment"
+	colBalconyCount = "balcony_count"
+	colBalconyType  = "balcony_type"
+	colBalconySash  = "balcony_sash"
+	colLoggias      = "loggia_details"
+	colAddons       = "addons"
+	colComment      = "comment"
+	colIntercom     = "intercom_code"
+	colPresence     = "presence_required"
+	colPreferred    = "preferred_time"
+	colNick         = "telegram_nick"
+	colName         = "customer_name"
+	colPhone        = "customer_phone"
+	colCreatedAt    = "created_at"
+	colStatus       = "status"
+)
+
+var importColumnAliases = map[string][]string{
+	colEntrance:     {"подъезд"},
+	colFloor:        {"этаж"},
+	colApartment:    {"квартира", "кв"},
+	colBalconyCount: {"лоджии"},
+	colBalconyType:  {"тип лоджии"},
+	colBalconySash:  {"створки лоджии"},
+	colLoggias:      {"список лоджий"},
+	colAddons:       {"доп. услуги", "дополнительные услуги"},
+	colComment:      {"комментарий"},
+	colIntercom:     {"код домофона", "домофон"},
+	colPresence:     {"нужно присутствие", "присутствие"},
+	colPreferred:    {"удобное время", "время"},
+	colNick:         {"телеграм ник", "ник из заказа", "ник"},
+	colName:         {"имя клиента", "клиент"},
+	colPhone:        {"телефон клиента", "телефон"},
+	colCreatedAt:    {"дата создания", "дата"},
+	colStatus:       {"статус"},
+}
+
+// importColumnTitles — ключи каталога с заголовками CSV-выгрузки: файл, выгруженный
+// на любом языке, можно загрузить обратно
+var importColumnTitles = map[string]string{
+	colEntrance:     "csv.entrance",
+	colFloor:        "csv.floor",
+	colApartment:    "csv.apartment",
+	colBalconyCount: "csv.balcony_count",
+	colBalconyType:  "csv.balcony_type",
+	colBalconySash:  "csv.balcony_sash",
+	colLoggias:      "csv.loggias",
+	colAddons:       "csv.addons",
+	colComment:      "csv.comment",
+	colIntercom:     "csv.intercom_code",
+	colPresence:     "csv.presence_required",
+	colPreferred:    "csv.preferred_time",
+	colNick:         "csv.telegram_nick",
+	colName:         "csv.customer_name",
+	colPhone:        "csv.customer_phone",
+	colCreatedAt:    "csv.created_at",
+	colStatus:       "csv.status",
+}
+
+// Колонки с количеством изделий называются кодом позиции каталога (или «<код>_count»)
+// либо ее названием на любом языке
+const colItemPrefix = "item:"
+
+// legacyItemAliases — названия колонок окон из старых таблиц
+var legacyItemAliases = map[string][]string{
+	"window_3":   {"3 створки"},
+	"window_4":   {"4 створки"},
+	"window_5":   {"5 створок"},
+	"window_6_7": {"6-7 створок"},
+}
+
+// ImportRowError — ошибка в строке файла импорта
+type ImportRowError struct {
+	Row     int // номер строки в файле, начиная с 1
+	Message string
+	Values  []string
+}
+
+// ImportResult — итог загрузки заказов
+type ImportResult struct {
+	Total    int // строк с данными в файле
+	Imported int
+	Header   []string
+	Errors   []ImportRowError
+}
+
+// ImportOrders проверяет строки файла CSV или XLSX по тем же правилам, что и диалог заказа,
+// рассчитывает стоимость и сохраняет корректные заказы одной транзакцией.
+// Строки с ошибками и дубликаты возвращаются в результате и не сохраняются.
+// Без колонки статуса заказы загружаются подтвержденными; остальные статусы сохраняются
+// как история квартиры и не становятся актуальными заказами.
+func ImportOrders(db *storage.Postgres, fileName string, data []byte, createdBy int64) (ImportResult, error) {
+	rows, err := readImportFile(fileName, data)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if len(rows) == 0 {
+		return ImportResult{}, fmt.Errorf("файл пуст")
+	}
+
+	header := rows[0]
+	columns, err := mapImportColumns(header)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	result := ImportResult{Header: header}
+	var orders []models.Order
+	var orderRows []int // номер строки файла для каждого заказа из orders
+	seen := make(map[string]int)
+
+	for i, values := range rows[1:] {
+		rowNum := i + 2
+		if isBlankRow(values) {
+			continue
+		}
+		result.Total++
+
+		order, err := parseImportRow(columns, values)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: rowNum, Message: err.Error(), Values: values})
+			continue
+		}
+
+		// Актуальным может быть только один подтвержденный заказ квартиры
+		if order.Status == "confirmed" {
+			key := fmt.Sprintf("%d/%d/%s", order.Entrance, order.Floor, order.Apartment)
+			if first, ok := seen[key]; ok {
+				result.Errors = append(result.Errors, ImportRowError{
+					Row:     rowNum,
+					Message: fmt.Sprintf("квартира уже указана в строке %d", first),
+					Values:  values,
+				})
+				continue
+			}
+			seen[key] = rowNum
+		}
+
+		order.CreatedBy = createdBy
+		orders = append(orders, order)
+		orderRows = append(orderRows, rowNum)
+	}
+
+	if len(orders) == 0 {
+		return result, nil
+	}
+
+	duplicates, err := db.ImportOrders(orders)
+	if err != nil {
+		return result, err
+	}
+	for _, index := range duplicates {
+		rowNum := orderRows[index]
+		result.Errors = append(result.Errors, ImportRowError{
+			Row:     rowNum,
+			Message: "для квартиры уже есть подтвержденный заказ",
+			Values:  rows[rowNum-1],
+		})
+	}
+	result.Imported = len(orders) - len(duplicates)
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+
+	return result, nil
+}
+
+// readImportFile читает строки файла; формат определяется по расширению и содержимому
+func readImportFile(fileName string, data []byte) ([][]string, error) {
+	if strings.HasSuffix(strings.ToLower(fileName), ".xlsx") || bytes.HasPrefix(data, []byte("PK")) {
+		return xlsx.ReadFirstSheet(data)
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// Выгрузка бота использует ";", таблицы из других программ — часто ","
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if strings.Count(firstLine, ";") >= strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения CSV: %v", err)
+	}
+	return rows, nil
+}
+
+// mapImportColumns сопоставляет колонки заголовка с полями заказа; неизвестные колонки пропускаются
+func mapImportColumns(header []string) (map[string]int, error) {
+	lookup := make(map[string]string)
+	for column, aliases := range importColumnAliases {
+		lookup[column] = column
+		for _, alias := range aliases {
+			lookup[alias] = column
+		}
+	}
+	for _, item := range catalogItems() {
+		column := colItemPrefix + item.Code
+		names := append([]string{item.Code, item.Code + "_count"}, legacyItemAliases[item.Code]...)
+		for _, title := range item.Titles {
+			names = append(names, title)
+		}
+		for _, name := range names {
+			if name = strings.ToLower(name); lookup[name] == "" {
+				lookup[name] = column
+			}
+		}
+	}
+	for column, key := range importColumnTitles {
+		for _, lang := range i18n.Languages {
+			title := strings.ToLower(i18n.T(lang, key))
+			if _, taken := lookup[title]; !taken {
+				lookup[title] = column
+			}
+		}
+	}
+
+	columns := make(map[string]int)
+	for i, title := range header {
+		if column, ok := lookup[strings.ToLower(strings.TrimSpace(title))]; ok {
+			if _, dup := columns[column]; !dup {
+				columns[column] = i
+			}
+		}
+	}
+
+	var missing []string
+	for _, required := range []string{colEntrance, colFloor, colApartment} {
+		if _, ok := columns[required]; !ok {
+			missing = append(missing, importColumnAliases[required][0])
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("в заголовке нет обязательных колонок: %s", strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// parseImportRow проверяет строку и собирает из нее заказ
+func parseImportRow(columns map[string]int, values []string) (models.Order, error) {
+	get := func(column string) string {
+		if i, ok := columns[column]; ok && i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+	number := func(column, title string, min, max int) (int, error) {
+		value := get(column)
+		if value == "" {
+			if min > 0 {
+				return 0, fmt.Errorf("не заполнено: %s", title)
+			}
+			return 0, nil
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(value, ".0"))
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("%s: нужно число от %d до %d", title, min, max)
+		}
+		return n, nil
+	}
+
+	var order models.Order
+	var err error
+
+	if order.Entrance, err = number(colEntrance, "подъезд", 1, maxEntrance); err != nil {
+		return order, err
+	}
+	if order.Floor, err = number(colFloor, "этаж", 1, maxFloor); err != nil {
+		return order, err
+	}
+	apartment, err := number(colApartment, "номер квартиры", 1, maxApartment)
+	if err != nil {
+		return order, err
+	}
+	order.Apartment = strconv.Itoa(apartment)
+
+	for _, item := range catalogItems() {
+		count, err := number(colItemPrefix+item.Code, "количество: "+itemTitle(i18n.Default, item), 0, maxWindowCount())
+		if err != nil {
+			return order, err
+		}
+		order.SetQuantity(item.Code, count)
+	}
+	loggiaCount, err := number(colBalconyCount, "количество лоджий", 0, maxBalconyCount)
+	if err != nil {
+		return order, err
+	}
+
+	// Окна считаются одинаковыми, если указан только один тип
+	order.WindowsSame = len(order.Items) == 1
+
+	// Лоджии указываются списком в колонке выгрузки или, как в старых таблицах,
+	// количеством с одним типом и створками для всех
+	if value := get(colLoggias); value != "" {
+		if order.Loggias, err = parseLoggias(value); err != nil {
+			return order, err
+		}
+		if get(colBalconyCount) != "" && loggiaCount != len(order.Loggias) {
+			return order, fmt.Errorf("количество лоджий (%d) не совпадает со списком лоджий (%d)", loggiaCount, len(order.Loggias))
+		}
+	} else if loggiaCount > 0 {
+		var loggia models.Loggia
+		if loggia.Type, err = parseBalconyType(get(colBalconyType)); err != nil {
+			return order, err
+		}
+		if loggia.Sash, err = parseSash(get(colBalconySash)); err != nil {
+			return order, err
+		}
+		for i := 0; i < loggiaCount; i++ {
+			order.Loggias = append(order.Loggias, loggia)
+		}
+	}
+	if len(order.Items) == 0 && len(order.Loggias) == 0 {
+		return order, errEmptyOrder
+	}
+	if value := get(colAddons); value != "" {
+		if order.Addons, err = parseAddons(value); err != nil {
+			return order, err
+		}
+	}
+
+	order.Comment = get(colComment)
+	if order.IntercomCode = get(colIntercom); utf8.RuneCountInString(order.IntercomCode) > maxIntercomCodeLength {
+		return order, fmt.Errorf("код домофона «%s» длиннее %d символов", order.IntercomCode, maxIntercomCodeLength)
+	}
+	if order.Presence, err = parseYesNo(get(colPresence)); err != nil {
+		return order, err
+	}
+	if order.PreferredTime, err = parsePreferredTime(get(colPreferred)); err != nil {
+		return order, err
+	}
+
+	if value := get(colNick); value != "" {
+		nick, ok := NormalizeTelegramNick(value)
+		if !ok {
+			return order, fmt.Errorf("некорректный ник «%s»", value)
+		}
+		order.TelegramNick = nick
+	}
+	order.CustomerName = get(colName)
+	if phone := get(colPhone); phone != "" {
+		normalized, ok := NormalizePhone(phone)
+		if !ok {
+			return order, fmt.Errorf("некорректный телефон «%s»", phone)
+		}
+		order.CustomerPhone = normalized
+	}
+	if value := get(colCreatedAt); value != "" {
+		if order.CreatedAt, err = parseImportDate(value); err != nil {
+			return order, err
+		}
+	}
+	if order.Status, err = parseImportStatus(get(colStatus)); err != nil {
+		return order, err
+	}
+
+	order.Source = models.OrderSourceImport
+	if order.Price, err = CalculatePrice(&order); err != nil {
+		return order, err
+	}
+	return order, nil
+}
+
+// parseLoggias разбирает список лоджий в формате выгрузки: «тип створки [остекление]» через «;».
+// Принимается и запись из XLSX-выгрузки: «до пола, 6-7 створки, теплое остекление».
+func parseLoggias(value string) ([]models.Loggia, error) {
+	var loggias []models.Loggia
+	for _, item := range strings.Split(value, ";") {
+		text := strings.ReplaceAll(strings.ToLower(item), "до пола", models.LoggiaFloor)
+		var fields []string
+		for _, field := range strings.Fields(strings.ReplaceAll(text, ",", " ")) {
+			if field != "створки" && field != "остекление" {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("некорректная лоджия «%s»: укажите тип, створки и, если известно, остекление", strings.TrimSpace(item))
+		}
+
+		var loggia models.Loggia
+		var err error
+		if loggia.Type, err = parseBalconyType(fields[0]); err != nil {
+			return nil, err
+		}
+		if loggia.Sash, err = parseSash(fields[1]); err != nil {
+			return nil, err
+		}
+		if len(fields) == 3 {
+			if loggia.Glazing, err = parseGlazing(fields[2]); err != nil {
+				return nil, err
+			}
+		}
+		loggias = append(loggias, loggia)
+	}
+	if len(loggias) > maxBalconyCount {
+		return nil, fmt.Errorf("лоджий: нужно не больше %d", maxBalconyCount)
+	}
+	return loggias, nil
+}
+
+// parseAddons разбирает дополнительные услуги: коды или названия на любом языке через «;».
+// Количество из XLSX-выгрузки («Мытье рам × 4») не учитывается: оно пересчитывается по заказу.
+func parseAddons(value string) ([]models.OrderAddon, error) {
+	var addons []models.OrderAddon
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		part, _, _ = strings.Cut(part, "×")
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		code := ""
+		for _, addon := range catalogAddons() {
+			if strings.ToLower(addon.Code) == name {
+				code = addon.Code
+			}
+			for _, title := range addon.Titles {
+				if strings.ToLower(title) == name {
+					code = addon.Code
+				}
+			}
+			if code != "" {
+				break
+			}
+		}
+		if code == "" {
+			return nil, fmt.Errorf("неизвестная дополнительная услуга «%s»", strings.TrimSpace(part))
+		}
+		if !seen[code] {
+			seen[code] = true
+			addons = append(addons, models.OrderAddon{AddonType: code})
+		}
+	}
+	return addons, nil
+}
+
+func parseBalconyType(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case models.LoggiaStandard, "стандартные", "стандартная":
+		return models.LoggiaStandard, nil
+	case models.LoggiaFloor, "до пола":
+		return models.LoggiaFloor, nil
+	}
+	return "", fmt.Errorf("некорректный тип лоджии «%s»: укажите «стандартные» или «до пола»", value)
+}
+
+func parseSash(value string) (string, error) {
+	value = strings.TrimSuffix(strings.ToLower(value), "-створчатые")
+	switch value {
+	case "3", "4", "5":
+		return value, nil
+	case "6_7", "6-7":
+		return "6_7", nil
+	}
+	return "", fmt.Errorf("некорректные створки лоджии «%s»: укажите 3, 4, 5 или 6-7", value)
+}
+
+func parseGlazing(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case models.GlazingCold, "холодное":
+		return models.GlazingCold, nil
+	case models.GlazingWarm, "теплое", "тёплое":
+		return models.GlazingWarm, nil
+	}
+	return "", fmt.Errorf("некорректное остекление лоджии «%s»: укажите «холодное» или «теплое»", value)
+}
+
+// parseYesNo разбирает отметку «да/нет» выгрузки; пустое значение — «нет»
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "false", "нет", "no", "0":
+		return false, nil
+	case "true", "да", "yes", "1":
+		return true, nil
+	}
+	return false, fmt.Errorf("некорректная отметка «%s»: укажите «да» или «нет»", value)
+}
+
+// parsePreferredTime разбирает удобное время: код из CSV-выгрузки или название на любом языке
+func parsePreferredTime(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	for _, preferred := range preferredTimes {
+		if strings.EqualFold(value, preferred) {
+			return preferred, nil
+		}
+		for _, lang := range i18n.Languages {
+			if strings.EqualFold(value, i18n.T(lang, "time."+preferred)) ||
+				strings.EqualFold(value, i18n.T(lang, "btn.time_"+preferred)) {
+				return preferred, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("некорректное удобное время «%s»: укажите «утром», «днем» или «вечером»", value)
+}
+
+// parseImportStatus разбирает статус: код из CSV-выгрузки или название на любом языке.
+// Пустой статус означает подтвержденный заказ. Заявки на расчет не загружаются:
+// цену по ним согласовывают с клиентом в боте.
+func parseImportStatus(value string) (string, error) {
+	if value == "" {
+		return "confirmed", nil
+	}
+	for status := range orderStatuses {
+		match := strings.EqualFold(value, status)
+		for _, lang := range i18n.Languages {
+			match = match || strings.EqualFold(value, i18n.T(lang, "status."+status))
+		}
+		if !match {
+			continue
+		}
+		if status == models.StatusAwaitingQuote {
+			return "", fmt.Errorf("заявку на расчет нельзя загрузить: цена не согласована с клиентом")
+		}
+		return status, nil
+	}
+	return "", fmt.Errorf("неизвестный статус «%s»", value)
+}
+
+// parseImportDate разбирает дату в форматах выгрузки или серийный номер даты Excel
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "02.01.2006 15:04", "02.01.2006"} {
+		if t, err := time.ParseInLocation(layout, value, clock.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		return xlsx.DateFromSerial(serial, clock.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("некорректная дата «%s»", value)
+}
+
+func isBlankRow(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportErrorsCSV формирует файл с ошибками: номер строки, описание и исходные значения
+func ImportErrorsCSV(result ImportResult) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+
+	if err := writer.Write(append([]string{"Строка", "Ошибка"}, result.Header...)); err != nil {
+		return nil, err
+	}
+	for _, rowErr := range result.Errors {
+		record := append([]string{strconv.Itoa(rowErr.Row), rowErr.Message}, rowErr.Values...)
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// handleImport обрабатывает команду /import: бот ждет файл CSV или XLSX
+func (b *Bot) handleImport(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	if !b.requirePermission(chatID, PermImportOrders) {
+		return
+	}
+
+	session := b.getSession(chatID)
+	session.CurrentState = StateAdminImport
+	b.sendMessage(chatID, "Отправьте файл CSV или XLSX с заказами.\n\n"+
+		"Обязательные колонки: Подъезд, Этаж, Квартира.\n"+
+		"Дополнительно: 3-створчатые, 4-створчатые, 5-створчатые, 6-7-створчатые, "+
+		"Лоджии, Тип лоджии, Створки лоджии, Телеграм ник, Имя клиента, Телефон клиента, Дата создания, Статус.\n"+
+		"Подходит и файл выгрузки /export.")
+}
+
+// handleImportDocument загружает присланный файл и импортирует заказы
+func (b *Bot) handleImportDocument(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	if msg.Document == nil {
+		b.sendMessage(chatID, "Пришлите файл CSV или XLSX документом.")
+		return
+	}
+	if !b.requirePermission(chatID, PermImportOrders) {
+		return
+	}
+	if msg.Document.FileSize > maxImportFileSize {
+		b.sendMessage(chatID, "Файл слишком большой, максимум 10 МБ.")
+		return
+	}
+
+	data, err := b.downloadFile(msg.Document.FileID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка загрузки файла: %v", err)
+		b.sendMessage(chatID, "Не удалось загрузить файл. Попробуйте еще раз.")
+		return
+	}
+
+	result, err := ImportOrders(b.db, msg.Document.FileName, data, msg.From.ID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка импорта заказов: %v", err)
+		b.sendMessage(chatID, "Не удалось загрузить заказы: "+err.Error())
+		return
+	}
+	b.getSession(chatID).CurrentState = ""
+
+	b.sendMessage(chatID, fmt.Sprintf("Импорт завершен.\nСтрок в файле: %d\nЗагружено заказов: %d\nС ошибками: %d",
+		result.Total, result.Imported, len(result.Errors)))
+	if len(result.Errors) == 0 {
+		return
+	}
+
+	report, err := ImportErrorsCSV(result)
+	if err != nil {
+		log.Printf("⚠️ Ошибка формирования отчета об ошибках: %v", err)
+		return
+	}
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: "import_errors.csv", Bytes: report})
+	doc.Caption = "Строки, которые не были загружены"
+	if _, err := b.api.Send(doc); err != nil {
+		log.Printf("⚠️ Ошибка отправки отчета об ошибках: %v", err)
+	}
+}
+
+// downloadFile скачивает файл, присланный боту
+func (b *Bot) downloadFile(fileID string) ([]byte, error) {
+	url, err := b.api.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ответ сервера: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize))
+}
@@ -0,0 +1,331 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+func TestParseLoggias(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []models.Loggia
+		wantErr bool
+	}{
+		{
+			value: "standard 3",
+			want:  []models.Loggia{{Type: models.LoggiaStandard, Sash: "3"}},
+		},
+		{
+			value: "standard 3 warm; floor 6_7",
+			want: []models.Loggia{
+				{Type: models.LoggiaStandard, Sash: "3", Glazing: models.GlazingWarm},
+				{Type: models.LoggiaFloor, Sash: "6_7"},
+			},
+		},
+		{
+			value: "До пола 6-7 холодное;  ; стандартные 5-створчатые",
+			want: []models.Loggia{
+				{Type: models.LoggiaFloor, Sash: "6_7", Glazing: models.GlazingCold},
+				{Type: models.LoggiaStandard, Sash: "5"},
+			},
+		},
+		{
+			value: "до пола, 4 створки, теплое остекление; стандартные, 3 створки",
+			want: []models.Loggia{
+				{Type: models.LoggiaFloor, Sash: "4", Glazing: models.GlazingWarm},
+				{Type: models.LoggiaStandard, Sash: "3"},
+			},
+		},
+		{value: "", want: nil},
+		{value: "standard", wantErr: true},
+		{value: "standard 3 warm extra", wantErr: true},
+		{value: "panoramic 3", wantErr: true},
+		{value: "standard 8", wantErr: true},
+		{value: "standard 3 hot", wantErr: true},
+		{value: "standard 3; standard 4; standard 5; floor 3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLoggias(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLoggias(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLoggias(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	useTestCatalog(t)
+	t.Setenv("MAX_WINDOW_COUNT", "")
+
+	header := []string{"Подъезд", "Этаж", "Квартира", "3-створчатые", "window_4_count", "Лоджии",
+		"Тип лоджии", "Створки лоджии", "Список лоджий", "Доп. услуги", "Нужно присутствие", "Удобное время",
+		"Код домофона", "Ник", "Имя клиента", "Телефон", "Дата создания", "Статус"}
+	columns, err := mapImportColumns(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// row собирает строку файла: значения по названиям колонок заголовка
+	row := func(values map[string]string) []string {
+		record := make([]string, len(header))
+		for i, title := range header {
+			record[i] = values[title]
+		}
+		return record
+	}
+	address := map[string]string{"Подъезд": "2", "Этаж": "5", "Квартира": "77"}
+	with := func(values map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range address {
+			merged[k] = v
+		}
+		for k, v := range values {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		check   func(t *testing.T, order models.Order)
+		wantErr string
+	}{
+		{
+			name:   "окна одного типа",
+			values: with(map[string]string{"3-створчатые": "2"}),
+			check: func(t *testing.T, order models.Order) {
+				if order.Entrance != 2 || order.Floor != 5 || order.Apartment != "77" {
+					t.Errorf("address = %d/%d/%s", order.Entrance, order.Floor, order.Apartment)
+				}
+				if !order.WindowsSame || order.Quantity("window_3") != 2 || order.Price != 2000 {
+					t.Errorf("order = %+v", order)
+				}
+				if order.Source != models.OrderSourceImport || order.Status != "confirmed" {
+					t.Errorf("source = %q, status = %q", order.Source, order.Status)
+				}
+			},
+		},
+		{
+			name:   "количество из Excel записано дробным числом",
+			values: with(map[string]string{"Этаж": "5.0", "window_4_count": "3.0", "3-створчатые": "1"}),
+			check: func(t *testing.T, order models.Order) {
+				if order.Floor != 5 || order.WindowsSame || order.Price != 1000+3*1500 {
+					t.Errorf("order = %+v", order)
+				}
+			},
+		},
+		{
+			name:   "статус по названию",
+			values: with(map[string]string{"3-створчатые": "1", "Статус": "На уточнении"}),
+			check: func(t *testing.T, order models.Order) {
+				if order.Status != "needs_clarification" {
+					t.Errorf("status = %q", order.Status)
+				}
+			},
+		},
+		{
+			name:   "лоджии старого формата: количество, тип и створки",
+			values: with(map[string]string{"Лоджии": "2", "Тип лоджии": "до пола", "Створки лоджии": "4"}),
+			check: func(t *testing.T, order models.Order) {
+				want := []models.Loggia{{Type: models.LoggiaFloor, Sash: "4"}, {Type: models.LoggiaFloor, Sash: "4"}}
+				if !reflect.DeepEqual(order.Loggias, want) || order.Price != 2*2000 {
+					t.Errorf("loggias = %+v, price = %d", order.Loggias, order.Price)
+				}
+			},
+		},
+		{
+			name:   "список лоджий и услуги",
+			values: with(map[string]string{"Список лоджий": "standard 3 warm", "3-створчатые": "1", "Доп. услуги": "frames; Мытье подоконников"}),
+			check: func(t *testing.T, order models.Order) {
+				if len(order.Loggias) != 1 || len(order.Addons) != 2 {
+					t.Fatalf("loggias = %+v, addons = %+v", order.Loggias, order.Addons)
+				}
+				if want := 1000 + 1000 + 2*300 + 200; order.Price != want {
+					t.Errorf("price = %d, want %d", order.Price, want)
+				}
+			},
+		},
+		{
+			name: "пожелания, клиент и дата",
+			values: with(map[string]string{"3-створчатые": "1", "Нужно присутствие": "да", "Удобное время": "вечером",
+				"Код домофона": "77К1234", "Ник": "t.me/ivan_petrov", "Имя клиента": "Иван", "Телефон": "8 900 123-45-67",
+				"Дата создания": "2026-04-01 10:30"}),
+			check: func(t *testing.T, order models.Order) {
+				if !order.Presence || order.PreferredTime != models.PreferredTimeEvening || order.IntercomCode != "77К1234" {
+					t.Errorf("notes = %v, %q, %q", order.Presence, order.PreferredTime, order.IntercomCode)
+				}
+				if order.TelegramNick != "ivan_petrov" || order.CustomerName != "Иван" || order.CustomerPhone != "+79001234567" {
+					t.Errorf("customer = %q, %q, %q", order.TelegramNick, order.CustomerName, order.CustomerPhone)
+				}
+				if want := time.Date(2026, 4, 1, 10, 30, 0, 0, clock.Location()); !order.CreatedAt.Equal(want) {
+					t.Errorf("created_at = %v, want %v", order.CreatedAt, want)
+				}
+			},
+		},
+		{name: "нет окон и лоджий", values: with(nil), wantErr: errEmptyOrder.Error()},
+		{name: "подъезд вне диапазона", values: with(map[string]string{"Подъезд": "7", "3-створчатые": "1"}), wantErr: "подъезд"},
+		{name: "не заполнен этаж", values: with(map[string]string{"Этаж": "", "3-створчатые": "1"}), wantErr: "этаж"},
+		{name: "квартира не число", values: with(map[string]string{"Квартира": "12а", "3-створчатые": "1"}), wantErr: "номер квартиры"},
+		{name: "слишком много окон", values: with(map[string]string{"3-створчатые": "31"}), wantErr: "3-створчатые"},
+		{name: "список лоджий не совпадает с количеством", values: with(map[string]string{"Лоджии": "2", "Список лоджий": "standard 3"}), wantErr: "не совпадает"},
+		{name: "неизвестная услуга", values: with(map[string]string{"3-створчатые": "1", "Доп. услуги": "полировка"}), wantErr: "полировка"},
+		{name: "некорректная отметка", values: with(map[string]string{"3-створчатые": "1", "Нужно присутствие": "может быть"}), wantErr: "может быть"},
+		{name: "некорректное время", values: with(map[string]string{"3-створчатые": "1", "Удобное время": "ночью"}), wantErr: "ночью"},
+		{name: "некорректный телефон", values: with(map[string]string{"3-створчатые": "1", "Телефон": "123"}), wantErr: "телефон"},
+		{name: "некорректный ник", values: with(map[string]string{"3-створчатые": "1", "Ник": "@иван"}), wantErr: "ник"},
+		{name: "неизвестный статус", values: with(map[string]string{"3-створчатые": "1", "Статус": "потерян"}), wantErr: "статус"},
+		{name: "заявка на расчет", values: with(map[string]string{"3-створчатые": "1", "Статус": "awaiting_quote"}), wantErr: "заявку на расчет"},
+		{name: "некорректная дата", values: with(map[string]string{"3-створчатые": "1", "Дата создания": "вчера"}), wantErr: "дата"},
+		{name: "длинный код домофона", values: with(map[string]string{"3-створчатые": "1", "Код домофона": strings.Repeat("1", maxIntercomCodeLength+1)}), wantErr: "домофона"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := parseImportRow(columns, row(tt.values))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseImportRow() error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImportRow() error = %v", err)
+			}
+			tt.check(t, order)
+		})
+	}
+}
+
+func TestMapImportColumnsRequired(t *testing.T) {
+	useTestCatalog(t)
+
+	if _, err := mapImportColumns([]string{"Подъезд", "Этаж", "3-створчатые"}); err == nil || !strings.Contains(err.Error(), "квартира") {
+		t.Errorf("error = %v, want a missing «квартира» column", err)
+	}
+	columns, err := mapImportColumns([]string{"Entrance", "FLOOR", " apartment ", "Floor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if columns[colEntrance] != 0 || columns[colFloor] != 1 || columns[colApartment] != 2 {
+		t.Errorf("columns = %v", columns)
+	}
+}
+
+// Выгруженный файл загружается обратно без потерь: адрес, окна, лоджии, услуги, пожелания, клиент и статус
+func TestExportImportRoundTrip(t *testing.T) {
+	useTestCatalog(t)
+	t.Setenv("MAX_WINDOW_COUNT", "")
+
+	created := time.Date(2026, 4, 1, 10, 30, 0, 0, clock.Location())
+	orders := []models.Order{
+		{
+			ID: 1, Entrance: 1, Floor: 3, Apartment: "12", WindowsSame: true,
+			Items:  []models.OrderItem{{ItemType: "window_4", Quantity: 3}},
+			Status: "confirmed", IsCurrent: true, Source: models.OrderSourceBot,
+			TelegramNick:  "ivan_petrov",
+			CustomerPhone: "+79001234567",
+			CreatedAt:     created,
+		},
+		{
+			ID: 2, Entrance: 6, Floor: 24, Apartment: "1500",
+			Items: []models.OrderItem{{ItemType: "window_3", Quantity: 2}, {ItemType: "door", Quantity: 1}},
+			Loggias: []models.Loggia{
+				{Type: models.LoggiaFloor, Sash: "6_7", Glazing: models.GlazingWarm},
+				{Type: models.LoggiaStandard, Sash: "3"},
+			},
+			Addons:        []models.OrderAddon{{AddonType: "frames"}, {AddonType: "trip"}},
+			Comment:       "собака дома",
+			IntercomCode:  "12К3456",
+			Presence:      true,
+			PreferredTime: models.PreferredTimeMorning,
+			Status:        "needs_clarification", Source: models.OrderSourcePhone,
+			CustomerName:  "Мария",
+			CustomerPhone: "+905321234567",
+			CreatedAt:     created.Add(time.Hour),
+		},
+		{
+			ID: 3, Entrance: 1, Floor: 3, Apartment: "12", WindowsSame: true,
+			Items:  []models.OrderItem{{ItemType: "window_5", Quantity: 1}},
+			Status: "canceled", Source: models.OrderSourceBot,
+			CreatedAt: created.Add(-24 * time.Hour),
+		},
+	}
+	for i := range orders {
+		price, err := CalculatePrice(&orders[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		orders[i].Price = price
+	}
+
+	b := &Bot{}
+	formats := map[string]func() ([]byte, error){
+		"orders.csv":  func() ([]byte, error) { return b.createCSV(orders, "en") },
+		"orders.xlsx": func() ([]byte, error) { return b.createXLSX(orders) },
+	}
+	for fileName, export := range formats {
+		t.Run(fileName, func(t *testing.T) {
+			data, err := export()
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows, err := readImportFile(fileName, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			columns, err := mapImportColumns(rows[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != len(orders)+1 {
+				t.Fatalf("rows = %d, want %d", len(rows), len(orders)+1)
+			}
+
+			for i, want := range orders {
+				got, err := parseImportRow(columns, rows[i+1])
+				if err != nil {
+					t.Fatalf("row %d: %v", i+2, err)
+				}
+				if got.Entrance != want.Entrance || got.Floor != want.Floor || got.Apartment != want.Apartment {
+					t.Errorf("row %d: address = %d/%d/%s", i+2, got.Entrance, got.Floor, got.Apartment)
+				}
+				if !reflect.DeepEqual(got.Items, want.Items) {
+					t.Errorf("row %d: items = %+v, want %+v", i+2, got.Items, want.Items)
+				}
+				if !reflect.DeepEqual(got.Loggias, want.Loggias) {
+					t.Errorf("row %d: loggias = %+v, want %+v", i+2, got.Loggias, want.Loggias)
+				}
+				if !reflect.DeepEqual(got.Addons, want.Addons) {
+					t.Errorf("row %d: addons = %+v, want %+v", i+2, got.Addons, want.Addons)
+				}
+				if got.Price != want.Price || got.WindowsSame != want.WindowsSame {
+					t.Errorf("row %d: price = %d, same = %v; want %d, %v", i+2, got.Price, got.WindowsSame, want.Price, want.WindowsSame)
+				}
+				if got.Comment != want.Comment || got.IntercomCode != want.IntercomCode ||
+					got.Presence != want.Presence || got.PreferredTime != want.PreferredTime {
+					t.Errorf("row %d: notes = %q, %q, %v, %q", i+2, got.Comment, got.IntercomCode, got.Presence, got.PreferredTime)
+				}
+				if got.TelegramNick != want.TelegramNick || got.CustomerName != want.CustomerName || got.CustomerPhone != want.CustomerPhone {
+					t.Errorf("row %d: customer = %q, %q, %q", i+2, got.TelegramNick, got.CustomerName, got.CustomerPhone)
+				}
+				if got.Status != want.Status {
+					t.Errorf("row %d: status = %q, want %q", i+2, got.Status, want.Status)
+				}
+				if !got.CreatedAt.Equal(want.CreatedAt) {
+					t.Errorf("row %d: created_at = %v, want %v", i+2, got.CreatedAt, want.CreatedAt)
+				}
+			}
+		})
+	}
+}
@@ -1,66 +1,132 @@
 package bot
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"fmt"
 
-func createMainMenuKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return tgbotapi.NewInlineKeyboardMarkup(
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Клавиатуры диалога заказа подписываются на языке клиента
+
+// createStartKeyboard — приветствие; вернувшимся клиентам предлагается повторить прошлый заказ
+func createStartKeyboard(lang string, hasLastOrder bool) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Новый заказ", "new_order"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.new_order"), "new_order"),
 		),
-	)
+	}
+	if hasLastOrder {
+		rows = append([][]tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.repeat_order"), "repeat_order"),
+			),
+		}, rows...)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
-func createEntranceKeyboard() tgbotapi.InlineKeyboardMarkup {
+func createMainMenuKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 1", "entrance_1"),
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 2", "entrance_2"),
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 3", "entrance_3"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.new_order"), "new_order"),
 		),
+	)
+}
+
+func backButtonRow(lang string) []tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.back"), "back"),
+	)
+}
+
+func createEntranceKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for entrance := 1; entrance <= maxEntrance; entrance++ {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			i18n.T(lang, "btn.entrance", entrance), fmt.Sprintf("entrance_%d", entrance)))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, backButtonRow(lang))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// createWindowsSameOrDifferentKeyboard — режим выбора окон; нестандартные окна считаются
+// по заявке, ответ на которую приходит в Telegram, поэтому в заказах по телефону их нет
+func createWindowsSameOrDifferentKeyboard(lang string, allowQuote bool) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 4", "entrance_4"),
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 5", "entrance_5"),
-			tgbotapi.NewInlineKeyboardButtonData("Подъезд 6", "entrance_6"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.windows_same"), "windows_same"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.windows_different"), "windows_different"),
 		),
+	}
+	if allowQuote {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.windows_custom"), "windows_custom"),
+		))
+	}
+	rows = append(rows, backButtonRow(lang))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// windowsModeKeyboard — клавиатура режима выбора окон для текущего заказа
+func (b *Bot) windowsModeKeyboard(chatID int64) tgbotapi.InlineKeyboardMarkup {
+	allowQuote := b.getSession(chatID).Order.Source != models.OrderSourcePhone
+	return createWindowsSameOrDifferentKeyboard(b.lang(chatID), allowQuote)
+}
+
+// createQuoteKeyboard — шаг заявки на нестандартные окна
+func createQuoteKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.quote_send"), "quote_send"),
 		),
+		backButtonRow(lang),
 	)
 }
 
-func createWindowsSameOrDifferentKeyboard() tgbotapi.InlineKeyboardMarkup {
+// createQuoteOfferKeyboard — ответ клиента на цену по заявке
+func createQuoteOfferKeyboard(lang string, orderID int64, price int) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Одинаковые", "windows_same"),
-			tgbotapi.NewInlineKeyboardButtonData("Разные", "windows_different"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.quote_accept"), fmt.Sprintf("quote_accept_%d_%d", orderID, price)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.quote_decline"), fmt.Sprintf("quote_decline_%d_%d", orderID, price)),
 		),
 	)
 }
 
-func createWindowTypesKeyboard() tgbotapi.InlineKeyboardMarkup {
+// createQuotePriceKeyboard — кнопка назначения цены по заявке для сотрудников
+func createQuotePriceKeyboard(orderID int64) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("3-створчатые", "window_3"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("4-створчатые", "window_4"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("5-створчатые", "window_5"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("6-7-створчатые", "window_6_7"),
-		),
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData("💰 Назначить цену", fmt.Sprintf("order_quote_%d", orderID)),
 		),
 	)
 }
 
-func createWindowCountKeyboard() tgbotapi.InlineKeyboardMarkup {
+// createWindowTypesKeyboard — типы окон из каталога изделий
+func createWindowTypesKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, item := range activeItems() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(itemTitle(lang, item), "item_"+item.Code),
+		))
+	}
+	rows = append(rows, backButtonRow(lang))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// createWindowCountKeyboard — быстрый выбор количества окон; больше можно набрать
+// счетчиком или ввести числом
+func createWindowCountKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("0", "count_0"),
@@ -74,71 +140,188 @@ func createWindowCountKeyboard() tgbotapi.InlineKeyboardMarkup {
 		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("6", "count_6"),
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.more"), fmt.Sprintf("count_step_%d", quickWindowCount+1)),
 		),
+		backButtonRow(lang),
 	)
 }
 
-func createBalconyNeededKeyboard() tgbotapi.InlineKeyboardMarkup {
+// createWindowStepperKeyboard — счетчик «−/+» для количества окон больше быстрых кнопок;
+// средняя кнопка подтверждает выбранное число
+func createWindowStepperKeyboard(lang string, count int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	if count > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("➖", fmt.Sprintf("count_step_%d", count-1)))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("✅ %d", count), fmt.Sprintf("count_%d", count)))
+	if count < maxWindowCount() {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("➕", fmt.Sprintf("count_step_%d", count+1)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row, backButtonRow(lang))
+}
+
+func createBalconyNeededKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("1 лоджия", "balcony_1"),
-			tgbotapi.NewInlineKeyboardButtonData("2 лоджии", "balcony_2"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.N(lang, "btn.balcony_count", 1), "balcony_1"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.N(lang, "btn.balcony_count", 2), "balcony_2"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("3 лоджии", "balcony_3"),
-			tgbotapi.NewInlineKeyboardButtonData("Не нужно", "balcony_0"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.N(lang, "btn.balcony_count", 3), "balcony_3"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.balcony_none"), "balcony_0"),
 		),
+		backButtonRow(lang),
+	)
+}
+
+func createBalconyTypeKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.balcony_standard"), "balcony_standard"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.balcony_floor"), "balcony_floor"),
 		),
+		backButtonRow(lang),
 	)
 }
 
-func createBalconyTypeKeyboard() tgbotapi.InlineKeyboardMarkup {
+func createBalconySashKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Стандартные", "balcony_standard"),
-			tgbotapi.NewInlineKeyboardButtonData("До пола", "balcony_floor"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.sash", "3"), "balcony_sash_3"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.sash", "4"), "balcony_sash_4"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.sash", "5"), "balcony_sash_5"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.sash", "6-7"), "balcony_sash_6_7"),
 		),
+		backButtonRow(lang),
 	)
 }
 
-func createBalconySashKeyboard() tgbotapi.InlineKeyboardMarkup {
+// createBalconyGlazingKeyboard — остекление лоджии; вопрос можно пропустить
+func createBalconyGlazingKeyboard(lang string) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("3-створчатые", "balcony_sash_3"),
-			tgbotapi.NewInlineKeyboardButtonData("4-створчатые", "balcony_sash_4"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.glazing_cold"), "glazing_cold"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.glazing_warm"), "glazing_warm"),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("5-створчатые", "balcony_sash_5"),
-			tgbotapi.NewInlineKeyboardButtonData("6-7-створчатые", "balcony_sash_6_7"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.glazing_unknown"), "glazing_unknown"),
 		),
+		backButtonRow(lang),
+	)
+}
+
+// createAddonsKeyboard — дополнительные услуги с ценой; выбранные отмечены галочкой
+func createAddonsKeyboard(lang string, addons []models.AddonType, order models.Order) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, addon := range addons {
+		label := i18n.T(lang, "btn.addon_"+addon.Unit, addonTitle(lang, addon), addon.Price)
+		if order.HasAddon(addon.Code) {
+			label = "✅ " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, "addon_"+addon.Code),
+		))
+	}
+	rows = append(rows,
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.addons_done"), "addons_done"),
 		),
+		backButtonRow(lang),
 	)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
-func createConfirmationKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return tgbotapi.NewInlineKeyboardMarkup(
+// createNotesKeyboard — шаг пожеланий: отметки включаются и выключаются нажатием
+func createNotesKeyboard(lang string, order models.Order) tgbotapi.InlineKeyboardMarkup {
+	mark := func(on bool, label string) string {
+		if on {
+			return "✅ " + label
+		}
+		return label
+	}
+
+	timeRow := tgbotapi.NewInlineKeyboardRow()
+	for _, preferred := range preferredTimes {
+		timeRow = append(timeRow, tgbotapi.NewInlineKeyboardButtonData(
+			mark(order.PreferredTime == preferred, i18n.T(lang, "btn.time_"+preferred)), "notes_time_"+preferred))
+	}
+	intercom := i18n.T(lang, "btn.intercom")
+	if order.IntercomCode != "" {
+		intercom = i18n.T(lang, "btn.intercom_set", order.IntercomCode)
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark(order.Presence, i18n.T(lang, "btn.presence")), "notes_presence"),
+		),
+		timeRow,
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Подтвердить", "confirm_order"),
-			tgbotapi.NewInlineKeyboardButtonData("Отменить", "cancel_order"),
+			tgbotapi.NewInlineKeyboardButtonData(intercom, "notes_intercom"),
 		),
+	}
+	if order.Comment != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.comment_clear"), "notes_comment_clear"),
+		))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.notes_done"), "notes_done"),
+		),
+		backButtonRow(lang),
 	)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
-func createSkipKeyboard() tgbotapi.InlineKeyboardMarkup {
-	return tgbotapi.NewInlineKeyboardMarkup(
+// createConfirmationKeyboard — подтверждение заказа с кнопками изменения каждого раздела.
+// В заказах по телефону ника нет, поэтому и кнопки для него нет; кнопка услуг есть,
+// только если к заказу применима хотя бы одна.
+func createConfirmationKeyboard(lang string, isPhoneOrder, hasAddons bool) tgbotapi.InlineKeyboardMarkup {
+	contactRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_phone"), "edit_phone"),
+	)
+	if !isPhoneOrder {
+		contactRow = append(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_nick"), "edit_nick"),
+		), contactRow...)
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.confirm"), "confirm_order"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.cancel"), "cancel_order"),
+		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Пропустить", "skip_nick"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_address"), "edit_address"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_windows"), "edit_windows"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_balcony"), "edit_balcony"),
 		),
+	}
+	if hasAddons {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_addons"), "edit_addons"),
+		))
+	}
+	contactRow = append(contactRow, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.edit_notes"), "edit_notes"))
+	rows = append(rows, contactRow)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// createNickKeyboard — шаг ника; если ник известен из профиля, его можно выбрать одной кнопкой
+func createNickKeyboard(lang, username string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if username != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.use_nick", username), "use_nick"),
+		))
+	}
+	rows = append(rows,
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Назад", "back"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "btn.skip"), "skip_nick"),
 		),
+		backButtonRow(lang),
 	)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
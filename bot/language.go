@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Кэш языков интерфейса пользователей. Заполняется при первом сообщении из профиля
+// или по языку клиента Telegram; читается и из фоновых горутин, поэтому защищен мьютексом.
+var (
+	userLanguages   = make(map[int64]string)
+	userLanguagesMu sync.Mutex
+)
+
+// lang возвращает язык пользователя; если его нет в кэше, язык читается из профиля
+func (b *Bot) lang(chatID int64) string {
+	userLanguagesMu.Lock()
+	language, ok := userLanguages[chatID]
+	userLanguagesMu.Unlock()
+	if ok {
+		return language
+	}
+
+	language = i18n.Default
+	if user, err := b.db.GetUser(chatID); err != nil {
+		log.Printf("⚠️ %v", err)
+		return language
+	} else if user != nil && i18n.IsSupported(user.Language) {
+		language = user.Language
+	}
+	b.setLang(chatID, language)
+	return language
+}
+
+func (b *Bot) setLang(chatID int64, language string) {
+	userLanguagesMu.Lock()
+	userLanguages[chatID] = language
+	userLanguagesMu.Unlock()
+}
+
+// detectLanguage определяет язык автора обновления: сохраненный в профиле выбор важнее
+// языка клиента Telegram
+func (b *Bot) detectLanguage(chatID int64, from *tgbotapi.User) {
+	userLanguagesMu.Lock()
+	_, ok := userLanguages[chatID]
+	userLanguagesMu.Unlock()
+	if ok || from == nil {
+		return
+	}
+
+	user, err := b.db.GetUser(chatID)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	if user != nil && i18n.IsSupported(user.Language) {
+		b.setLang(chatID, user.Language)
+		return
+	}
+	b.setLang(chatID, i18n.Detect(from.LanguageCode))
+}
+
+// t возвращает сообщение каталога на языке пользователя
+func (b *Bot) t(chatID int64, key string, args ...interface{}) string {
+	return i18n.T(b.lang(chatID), key, args...)
+}
+
+// handleLanguage обрабатывает команду /language
+func (b *Bot) handleLanguage(msg *tgbotapi.Message) {
+	lang := b.lang(msg.Chat.ID)
+	b.sendMessage(msg.Chat.ID, i18n.T(lang, "language.choose"), createLanguageKeyboard(lang))
+}
+
+// handleLanguageCallback сохраняет выбранный язык в профиле пользователя
+func (b *Bot) handleLanguageCallback(chatID int64, data string) {
+	language := strings.TrimPrefix(data, "lang_")
+	if !i18n.IsSupported(language) {
+		b.sendMessage(chatID, b.t(chatID, "unknown_action"))
+		return
+	}
+
+	if err := b.db.SetUserLanguage(chatID, language); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	b.setLang(chatID, language)
+	b.sendMessage(chatID, i18n.T(language, "language.changed", i18n.T(language, "language.name")))
+}
+
+// createLanguageKeyboard — список языков; текущий отмечен галочкой
+func createLanguageKeyboard(current string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, language := range i18n.Languages {
+		title := i18n.T(language, "language.name")
+		if language == current {
+			title = "✅ " + title
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(title, "lang_"+language),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Шаг пожеланий перед подтверждением: комментарий к заказу и условия доступа в квартиру.
+// Все поля необязательные — шаг можно пропустить кнопкой «Готово».
+const (
+	StateOrderNotes   = "order_notes"   // отметки доступа; текстовое сообщение становится комментарием
+	StateIntercomCode = "intercom_code" // клиент вводит код домофона
+)
+
+const (
+	maxCommentLength      = 500
+	maxIntercomCodeLength = 20
+)
+
+// preferredTimes — варианты удобного времени в порядке кнопок
+var preferredTimes = []string{models.PreferredTimeMorning, models.PreferredTimeAfternoon, models.PreferredTimeEvening}
+
+// askNotes переходит к шагу пожеланий
+func (b *Bot) askNotes(chatID int64) {
+	b.updateState(chatID, StateOrderNotes)
+	b.showNotesStep(chatID, "")
+}
+
+// showNotesStep показывает отметки доступа и комментарий; notice — пояснение над вопросом
+func (b *Bot) showNotesStep(chatID int64, notice string) {
+	order := b.getSession(chatID).Order
+	lang := b.lang(chatID)
+
+	prompt := i18n.T(lang, "order.notes")
+	if notice != "" {
+		prompt = notice + "\n\n" + prompt
+	}
+	if order.Comment != "" {
+		prompt += "\n\n" + i18n.T(lang, "order.notes_comment", order.Comment)
+	}
+	b.showStep(chatID, prompt, createNotesKeyboard(lang, order))
+}
+
+// handleNotesCallback обрабатывает кнопки шага пожеланий
+func (b *Bot) handleNotesCallback(chatID int64, data string) {
+	session := b.getSession(chatID)
+	if session.CurrentState != StateOrderNotes {
+		b.sendMessage(chatID, b.t(chatID, "unknown_action"))
+		return
+	}
+
+	switch {
+	case data == "notes_presence":
+		session.Order.Presence = !session.Order.Presence
+	case strings.HasPrefix(data, "notes_time_"):
+		// Повторное нажатие на выбранное время снимает отметку
+		preferred := data[len("notes_time_"):]
+		if session.Order.PreferredTime == preferred {
+			preferred = ""
+		}
+		session.Order.PreferredTime = preferred
+	case data == "notes_comment_clear":
+		session.Order.Comment = ""
+	case data == "notes_intercom":
+		b.updateState(chatID, StateIntercomCode)
+		b.showStep(chatID, b.t(chatID, "order.intercom_enter", maxIntercomCodeLength), tgbotapi.NewInlineKeyboardMarkup(backButtonRow(b.lang(chatID))))
+		return
+	case data == "notes_done":
+		b.continueOrder(chatID, func() { b.showPricedConfirmation(chatID) })
+		return
+	}
+	b.showNotesStep(chatID, "")
+}
+
+// handleNotesText сохраняет комментарий, отправленный сообщением на шаге пожеланий
+func (b *Bot) handleNotesText(chatID int64, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		b.showNotesStep(chatID, "")
+		return
+	}
+	if utf8.RuneCountInString(text) > maxCommentLength {
+		b.showNotesStep(chatID, b.t(chatID, "order.comment_too_long", maxCommentLength))
+		return
+	}
+	b.getSession(chatID).Order.Comment = text
+	b.showNotesStep(chatID, "")
+}
+
+// handleIntercomCode сохраняет код домофона; «-» удаляет указанный ранее код
+func (b *Bot) handleIntercomCode(chatID int64, text string) {
+	code := strings.TrimSpace(text)
+	if code == "" || utf8.RuneCountInString(code) > maxIntercomCodeLength {
+		b.showStep(chatID, b.t(chatID, "order.intercom_invalid", maxIntercomCodeLength), tgbotapi.NewInlineKeyboardMarkup(backButtonRow(b.lang(chatID))))
+		return
+	}
+	if code == "-" {
+		code = ""
+	}
+
+	session := b.getSession(chatID)
+	session.Order.IntercomCode = code
+	// Код вводится внутри шага пожеланий: возвращаемся к нему, а не добавляем новый шаг в историю
+	session.CurrentState = StateOrderNotes
+	if n := len(session.PreviousStates); n > 0 && session.PreviousStates[n-1] == StateOrderNotes {
+		session.PreviousStates = session.PreviousStates[:n-1]
+	}
+	b.showNotesStep(chatID, "")
+}
+
+// isPreferredTime проверяет код удобного времени
+func isPreferredTime(value string) bool {
+	for _, preferred := range preferredTimes {
+		if value == preferred {
+			return true
+		}
+	}
+	return false
+}
+
+// preferredTimeTitle возвращает удобное время для выгрузок; пустое значение — в любое время
+func preferredTimeTitle(preferred string) string {
+	if preferred == "" {
+		return ""
+	}
+	return i18n.T(i18n.Default, "time."+preferred)
+}
+
+// accessNotes перечисляет условия доступа в квартиру: код домофона, присутствие клиента
+// и удобное время
+func accessNotes(lang string, order models.Order) []string {
+	var notes []string
+	if order.IntercomCode != "" {
+		notes = append(notes, i18n.T(lang, "notes.intercom", order.IntercomCode))
+	}
+	if order.Presence {
+		notes = append(notes, i18n.T(lang, "notes.presence"))
+	}
+	if order.PreferredTime != "" {
+		notes = append(notes, i18n.T(lang, "notes.time", i18n.T(lang, "time."+order.PreferredTime)))
+	}
+	return notes
+}
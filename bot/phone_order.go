@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	StateCustomerName  = "customer_name"
+	StateCustomerPhone = "customer_phone"
+)
+
+// handlePhoneOrder обрабатывает команду /neworder — заказ от имени клиента,
+// который позвонил по телефону. Дальше используются те же шаги, что и в обычном диалоге.
+func (b *Bot) handlePhoneOrder(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	if !b.requirePermission(chatID, PermCreateOrders) {
+		return
+	}
+
+	b.startOrderSession(chatID, models.Order{
+		Source:    models.OrderSourcePhone,
+		CreatedBy: chatID,
+	}, 0)
+	b.updateState(chatID, StateCustomerName)
+	b.showStep(chatID, b.t(chatID, "phone_order.start"))
+}
+
+func (b *Bot) handleCustomerName(chatID int64, text string) {
+	name := strings.TrimSpace(text)
+	if name == "" || utf8.RuneCountInString(name) > 200 {
+		b.showStep(chatID, b.t(chatID, "phone_order.invalid_name"))
+		return
+	}
+
+	session := b.getSession(chatID)
+	session.Order.CustomerName = name
+	b.updateState(chatID, StateCustomerPhone)
+	b.showStep(chatID, b.t(chatID, "phone_order.enter_phone"))
+}
+
+func (b *Bot) handleCustomerPhone(chatID int64, text string) {
+	phone, ok := NormalizePhone(text)
+	if !ok {
+		b.showStep(chatID, b.t(chatID, "phone_order.invalid_phone"))
+		return
+	}
+
+	session := b.getSession(chatID)
+	session.Order.CustomerPhone = phone
+	b.continueOrder(chatID, func() {
+		b.updateState(chatID, StateWaitingForEntrance)
+		b.showStep(chatID, b.t(chatID, "order.choose_entrance"), createEntranceKeyboard(b.lang(chatID)))
+	})
+}
+
+// customerContact возвращает описание клиента для уведомлений администраторам
+func customerContact(order models.Order) string {
+	if order.UserID == 0 {
+		return order.CustomerName + ", " + order.CustomerPhone + " (заказ по телефону)"
+	}
+
+	contact := strings.TrimSpace(order.User.FirstName + " " + order.User.LastName)
+	if order.User.UserName != "" {
+		contact += " @" + order.User.UserName
+	} else if order.TelegramNick != "" {
+		contact += " @" + strings.TrimPrefix(order.TelegramNick, "@")
+	} else {
+		// Без ника написать клиенту можно только по ссылке с Telegram ID
+		contact += " " + TelegramLink(order.UserID, "")
+	}
+	if order.CustomerPhone != "" {
+		contact += ", " + order.CustomerPhone
+	}
+	return strings.TrimSpace(contact + " (ID " + formatID(order.UserID) + ")")
+}
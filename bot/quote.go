@@ -0,0 +1,262 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/eugenepelipets/window-wash-bot/storage"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Нестандартные окна (панорамные, витражи, витрины) не укладываются в расчет по створкам:
+// клиент присылает фото и описание, заявка сохраняется со статусом awaiting_quote,
+// администратор назначает цену, и клиент принимает или отклоняет предложение.
+
+// StateQuoteDetails — клиент присылает фото и описание нестандартных окон
+const StateQuoteDetails = "quote_details"
+
+// maxQuotePhotos — сколько фото можно приложить к заявке (столько помещается в один альбом)
+const maxQuotePhotos = 10
+
+// Ключи TempData заявки на расчет
+const (
+	quotePhotosKey      = "quote_photos"
+	quoteDescriptionKey = "quote_description"
+	quoteMediaGroupKey  = "quote_media_group"
+)
+
+// handleQuoteStart начинает заявку на расчет нестандартных окон вместо выбора окон по створкам
+func (b *Bot) handleQuoteStart(chatID int64) {
+	session := b.getSession(chatID)
+	session.Order.Items = nil
+	session.Order.Loggias = nil
+	session.Order.Addons = nil
+	delete(session.TempData, editingKey)
+	delete(session.TempData, quotePhotosKey)
+	delete(session.TempData, quoteDescriptionKey)
+	delete(session.TempData, quoteMediaGroupKey)
+
+	b.updateState(chatID, StateQuoteDetails)
+	b.showQuoteStep(chatID, "")
+}
+
+// showQuoteStep показывает, что уже получено, и кнопку отправки заявки; notice — пояснение над вопросом
+func (b *Bot) showQuoteStep(chatID int64, notice string) {
+	session := b.getSession(chatID)
+	lang := b.lang(chatID)
+	photos, _ := session.TempData[quotePhotosKey].([]string)
+	description, _ := session.TempData[quoteDescriptionKey].(string)
+
+	var prompt strings.Builder
+	if notice != "" {
+		prompt.WriteString(notice + "\n\n")
+	}
+	prompt.WriteString(i18n.T(lang, "order.quote_intro", maxQuotePhotos))
+	if len(photos) > 0 || description != "" {
+		prompt.WriteString("\n\n" + i18n.T(lang, "order.quote_photos", len(photos), maxQuotePhotos))
+		if description != "" {
+			prompt.WriteString("\n" + i18n.T(lang, "order.quote_description", truncateText(description, 200)))
+		}
+	}
+	b.showStep(chatID, prompt.String(), createQuoteKeyboard(lang))
+}
+
+// handleQuoteMessage принимает фото и текст заявки. Фото одного альбома приходят отдельными
+// сообщениями — для них карточка меняется на месте, а не отправляется заново.
+func (b *Bot) handleQuoteMessage(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	session := b.getSession(chatID)
+	lang := b.lang(chatID)
+
+	lastGroup, _ := session.TempData[quoteMediaGroupKey].(string)
+	if msg.MediaGroupID == "" || msg.MediaGroupID != lastGroup {
+		b.supersedeCard(chatID)
+	}
+	session.TempData[quoteMediaGroupKey] = msg.MediaGroupID
+
+	text := strings.TrimSpace(msg.Text)
+	notice := ""
+	switch {
+	case len(msg.Photo) > 0:
+		photos, _ := session.TempData[quotePhotosKey].([]string)
+		if len(photos) >= maxQuotePhotos {
+			notice = i18n.T(lang, "order.quote_too_many", maxQuotePhotos)
+			break
+		}
+		// Последний размер фото — самый крупный
+		session.TempData[quotePhotosKey] = append(photos, msg.Photo[len(msg.Photo)-1].FileID)
+		text = strings.TrimSpace(msg.Caption)
+	case text == "":
+		notice = i18n.T(lang, "order.quote_photo_only")
+	}
+
+	if text != "" {
+		description, _ := session.TempData[quoteDescriptionKey].(string)
+		if description != "" {
+			description += "\n"
+		}
+		session.TempData[quoteDescriptionKey] = description + text
+	}
+	b.showQuoteStep(chatID, notice)
+}
+
+// handleQuoteSend сохраняет заявку и отправляет ее сотрудникам
+func (b *Bot) handleQuoteSend(chatID int64) {
+	session := b.getSession(chatID)
+	photos, _ := session.TempData[quotePhotosKey].([]string)
+	description, _ := session.TempData[quoteDescriptionKey].(string)
+	if len(photos) == 0 || description == "" {
+		b.supersedeCard(chatID)
+		b.showQuoteStep(chatID, b.t(chatID, "order.quote_incomplete"))
+		return
+	}
+
+	order := session.Order
+	order.WindowsSame = false
+	order.Items = nil
+	order.Loggias = nil
+	order.Addons = nil
+	order.Price = 0
+	order.Description = description
+	order.Photos = photos
+	// Контакты берем из профиля: цену клиент получит в этом чате
+	if user, err := b.db.GetUser(chatID); err != nil {
+		log.Printf("⚠️ %v", err)
+	} else if user != nil {
+		if nick, ok := NormalizeTelegramNick(user.UserName); ok {
+			order.TelegramNick = nick
+		}
+		order.CustomerPhone = user.Phone
+		order.User = *user
+	}
+
+	orderID, err := b.db.SaveQuoteRequest(order)
+	if err != nil {
+		log.Printf("⚠️ Ошибка сохранения заявки на расчет: %v", err)
+		b.sendMessage(chatID, b.t(chatID, "order.save_error"))
+		return
+	}
+	order.ID = orderID
+
+	b.showCard(chatID, b.t(chatID, "order.quote_sent", orderID))
+	delete(userSessions, chatID)
+	b.notifyQuoteRequest(order)
+}
+
+// notifyQuoteRequest отправляет сотрудникам фото заявки и кнопку назначения цены
+func (b *Bot) notifyQuoteRequest(order models.Order) {
+	text := fmt.Sprintf("🪟 Заявка №%d на расчет нестандартных окон\n\n"+
+		"Подъезд: %d\nЭтаж: %d\nКвартира: %s\nКлиент: %s\n\nОписание:\n%s",
+		order.ID, order.Entrance, order.Floor, order.Apartment, customerContact(order), order.Description)
+	markup := createQuotePriceKeyboard(order.ID)
+
+	for _, chatID := range b.staffRecipients(NotifyQuoteRequest) {
+		b.sendOrderPhotos(chatID, order.Photos)
+		b.sendMessage(chatID, text, markup)
+	}
+}
+
+// sendOrderPhotos отправляет фото заявки одним альбомом
+func (b *Bot) sendOrderPhotos(chatID int64, photos []string) {
+	switch len(photos) {
+	case 0:
+		return
+	case 1:
+		if _, err := b.api.Send(tgbotapi.NewPhoto(chatID, tgbotapi.FileID(photos[0]))); err != nil {
+			log.Printf("⚠️ Ошибка отправки фото: %v", err)
+		}
+		return
+	}
+
+	media := make([]interface{}, len(photos))
+	for i, fileID := range photos {
+		media[i] = tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(fileID))
+	}
+	if _, err := b.api.SendMediaGroup(tgbotapi.NewMediaGroup(chatID, media)); err != nil {
+		log.Printf("⚠️ Ошибка отправки фото: %v", err)
+	}
+}
+
+// sendQuoteOffer отправляет клиенту цену по заявке с кнопками «Принять» и «Отказаться».
+// Цена записана в кнопках: после новой цены кнопки прежнего предложения не действуют.
+func (b *Bot) sendQuoteOffer(order models.Order) {
+	lang := b.lang(order.UserID)
+	b.sendMessage(order.UserID, i18n.T(lang, "quote.offer", order.ID, order.Price),
+		createQuoteOfferKeyboard(lang, order.ID, order.Price))
+}
+
+// handleQuoteResponse обрабатывает ответ клиента на предложение цены.
+// Возвращает текст всплывающего уведомления, если нажатие отклонено.
+func (b *Bot) handleQuoteResponse(chatID int64, messageID int, data string) string {
+	// Кнопки имеют вид quote_<accept|decline>_<id>_<цена>
+	parts := strings.Split(strings.TrimPrefix(data, "quote_"), "_")
+	if len(parts) != 3 {
+		return b.t(chatID, "callback.invalid")
+	}
+	accept := parts[0] == "accept"
+	orderID, err1 := strconv.ParseInt(parts[1], 10, 64)
+	price, err2 := strconv.Atoi(parts[2])
+	if err1 != nil || err2 != nil {
+		return b.t(chatID, "callback.invalid")
+	}
+
+	order, err := b.db.GetOrderByID(orderID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения заявки: %v", err)
+		return b.t(chatID, "callback.invalid")
+	}
+	if order == nil || order.UserID != chatID {
+		return b.t(chatID, "callback.invalid")
+	}
+	if order.Status != models.StatusAwaitingQuote || order.Price != price {
+		b.removeInlineKeyboard(chatID, messageID)
+		return b.t(chatID, "quote.outdated")
+	}
+
+	if !accept {
+		order.Status = "canceled"
+		if err := b.db.UpdateOrder(*order, chatID); err != nil {
+			log.Printf("⚠️ Ошибка отмены заявки: %v", err)
+			b.sendMessage(chatID, b.t(chatID, "order.save_error"))
+			return ""
+		}
+		b.removeInlineKeyboard(chatID, messageID)
+		b.sendMessage(chatID, b.t(chatID, "quote.declined", order.ID), createMainMenuKeyboard(b.lang(chatID)))
+		b.notifyStaff(NotifyQuoteRequest, fmt.Sprintf("❌ Клиент отказался от цены по заявке №%d (%d руб.)", order.ID, order.Price))
+		return ""
+	}
+
+	status, err := b.db.AcceptQuote(order.ID, price, chatID)
+	if errors.Is(err, storage.ErrQuoteOutdated) {
+		b.removeInlineKeyboard(chatID, messageID)
+		return b.t(chatID, "quote.outdated")
+	}
+	if err != nil {
+		log.Printf("⚠️ Ошибка оформления заявки: %v", err)
+		b.sendMessage(chatID, b.t(chatID, "order.save_error"))
+		return ""
+	}
+	b.removeInlineKeyboard(chatID, messageID)
+	if status == "confirmed" {
+		b.sendMessage(chatID, b.t(chatID, "quote.accepted", order.ID))
+	} else {
+		b.sendMessage(chatID, b.t(chatID, "order.duplicate"))
+		b.notifyAdminAboutDuplicate(chatID, *order)
+	}
+	b.notifyStaff(NotifyQuoteRequest, fmt.Sprintf("✅ Клиент принял цену по заявке №%d: %d руб.", order.ID, order.Price))
+	return ""
+}
+
+// truncateText сокращает текст до limit символов
+func truncateText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit]) + "…"
+}
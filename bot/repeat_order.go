@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"log"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// editingKey — признак того, что клиент меняет одно поле заказа с экрана подтверждения:
+// после этого шага нужно вернуться к подтверждению, а не идти дальше по диалогу
+const editingKey = "editing"
+
+// handleRepeatOrder заполняет заказ данными из прошлого заказа клиента и сразу показывает
+// подтверждение со стоимостью по текущим ценам
+func (b *Bot) handleRepeatOrder(chatID int64, messageID int) {
+	last, err := b.db.GetLastOrder(chatID)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		b.sendMessage(chatID, b.t(chatID, "order.repeat_error"))
+		return
+	}
+	if last == nil {
+		b.handleNewOrder(chatID, messageID)
+		return
+	}
+
+	order := models.Order{
+		UserID:        chatID,
+		Source:        models.OrderSourceBot,
+		Entrance:      last.Entrance,
+		Floor:         last.Floor,
+		Apartment:     last.Apartment,
+		WindowsSame:   last.WindowsSame,
+		TelegramNick:  last.TelegramNick,
+		CustomerPhone: last.CustomerPhone,
+		Comment:       last.Comment,
+		IntercomCode:  last.IntercomCode,
+		Presence:      last.Presence,
+		PreferredTime: last.PreferredTime,
+	}
+	// Позиции, снятые с продажи, в новый заказ не переносятся
+	for _, item := range last.Items {
+		if itemType, ok := findItem(item.ItemType); ok && itemType.Active {
+			order.SetQuantity(item.ItemType, item.Quantity)
+		}
+	}
+	for _, loggia := range last.Loggias {
+		order.Loggias = append(order.Loggias, models.Loggia{
+			Type:    loggia.Type,
+			Sash:    loggia.Sash,
+			Glazing: loggia.Glazing,
+		})
+	}
+	for _, addon := range last.Addons {
+		if addonType, ok := findAddon(addon.AddonType); ok && addonType.Active {
+			order.Addons = append(order.Addons, models.OrderAddon{AddonType: addon.AddonType})
+		}
+	}
+	if last.User.Phone != "" {
+		order.CustomerPhone = last.User.Phone
+	}
+
+	// Приветствие остается над пояснением, поэтому карточкой заказа становится новое сообщение
+	b.startOrderSession(chatID, order, messageID)
+	b.supersedeCard(chatID)
+	b.sendMessage(chatID, b.t(chatID, "order.repeat_intro", last.CreatedAt.In(clock.Location()).Format("02.01.2006")))
+	b.showPricedConfirmation(chatID)
+}
+
+// handleEditOrder обрабатывает кнопки изменения заказа на экране подтверждения
+func (b *Bot) handleEditOrder(chatID int64, data string) {
+	session := b.getSession(chatID)
+	if session.CurrentState != StateWaitingConfirmation {
+		b.sendMessage(chatID, b.t(chatID, "order.already_done"))
+		return
+	}
+	isPhoneOrder := session.Order.Source == models.OrderSourcePhone
+	lang := b.lang(chatID)
+
+	// После шага (или нескольких шагов раздела) continueOrder вернет клиента к подтверждению
+	session.TempData[editingKey] = true
+	switch data {
+	case "edit_address":
+		b.updateState(chatID, StateWaitingForEntrance)
+		b.showStep(chatID, i18n.T(lang, "order.choose_entrance"), createEntranceKeyboard(lang))
+	case "edit_windows":
+		b.updateState(chatID, StateWindowsSameOrDifferent)
+		b.showStep(chatID, i18n.T(lang, "order.windows_same_or_different"),
+			b.windowsModeKeyboard(chatID))
+	case "edit_balcony":
+		b.askBalconyNeeded(chatID)
+	case "edit_addons":
+		b.askAddons(chatID)
+	case "edit_notes":
+		b.askNotes(chatID)
+	case "edit_nick":
+		b.updateState(chatID, StateTelegramNick)
+		b.sendNickRequest(chatID)
+	case "edit_phone":
+		if isPhoneOrder {
+			b.updateState(chatID, StateCustomerPhone)
+			b.showStep(chatID, b.t(chatID, "phone_order.enter_phone"))
+			return
+		}
+		b.updateState(chatID, StateContactPhone)
+		b.sendContactRequest(chatID)
+	default:
+		delete(session.TempData, editingKey)
+	}
+}
+
+// continueOrder переходит к следующему шагу диалога, а если клиент менял поле
+// с экрана подтверждения — возвращает к подтверждению
+func (b *Bot) continueOrder(chatID int64, next func()) {
+	session := b.getSession(chatID)
+	if editing, _ := session.TempData[editingKey].(bool); editing {
+		delete(session.TempData, editingKey)
+		b.showPricedConfirmation(chatID)
+		return
+	}
+	next()
+}
@@ -0,0 +1,478 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/cron"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// schedulerInterval — как часто планировщик проверяет, не пора ли запустить задачи
+const schedulerInterval = 30 * time.Second
+
+// builtinJob описывает встроенную задачу планировщика
+type builtinJob struct {
+	Name          string
+	Description   string
+	CronEnv       string // переменная окружения с расписанием по умолчанию
+	DefaultCron   string
+	DefaultParams string
+	Run           func(b *Bot, job models.ScheduledJob, scheduledFor time.Time) error
+}
+
+var builtinJobs = []builtinJob{
+	{
+		Name:          "daily_export",
+		Description:   "выгрузка заказов за день",
+		CronEnv:       "DAILY_EXPORT_CRON",
+		DefaultCron:   "0 21 * * *",
+		DefaultParams: "format=xlsx",
+		Run:           (*Bot).runDailyExport,
+	},
+	{
+		Name:        "weekly_revenue",
+		Description: "сводка по выручке за неделю",
+		CronEnv:     "WEEKLY_REVENUE_CRON",
+		DefaultCron: "0 10 * * 1",
+		Run:         (*Bot).runWeeklyRevenue,
+	},
+	{
+		Name:        "work_plan",
+		Description: "новые подтвержденные заказы для мойщиков",
+		CronEnv:     "WORK_PLAN_CRON",
+		DefaultCron: "0 19 * * *",
+		Run:         (*Bot).runWorkPlan,
+	},
+}
+
+func findBuiltinJob(name string) *builtinJob {
+	for i := range builtinJobs {
+		if builtinJobs[i].Name == name {
+			return &builtinJobs[i]
+		}
+	}
+	return nil
+}
+
+// runScheduler регистрирует встроенные задачи и периодически запускает наступившие
+func (b *Bot) runScheduler() {
+	for _, builtin := range builtinJobs {
+		cronExpr := builtin.DefaultCron
+		if env := os.Getenv(builtin.CronEnv); env != "" {
+			if _, err := cron.Parse(env); err != nil {
+				log.Printf("⚠️ Некорректное расписание в %s: %v", builtin.CronEnv, err)
+			} else {
+				cronExpr = env
+			}
+		}
+		err := b.db.EnsureJob(models.ScheduledJob{
+			Name:    builtin.Name,
+			Cron:    cronExpr,
+			Enabled: true,
+			Params:  builtin.DefaultParams,
+		})
+		if err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+	}
+
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		b.runDueJobs(clock.Now())
+		<-ticker.C
+	}
+}
+
+// runDueJobs запускает задачи, время которых наступило.
+// Если бот был остановлен и пропустил несколько запусков, выполняется только последний из них.
+func (b *Bot) runDueJobs(now time.Time) {
+	jobs, err := b.db.GetJobs()
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if !job.Enabled {
+			continue
+		}
+		builtin := findBuiltinJob(job.Name)
+		if builtin == nil {
+			continue
+		}
+		schedule, err := cron.Parse(job.Cron)
+		if err != nil {
+			log.Printf("⚠️ Некорректное расписание задачи %s: %v", job.Name, err)
+			continue
+		}
+
+		// Новая задача не догоняет запуски, которые были до ее создания
+		last := job.LastScheduledFor
+		if last.IsZero() {
+			last = job.CreatedAt
+		}
+
+		var due time.Time
+		for next := schedule.Next(last.In(now.Location())); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+			due = next
+		}
+		if due.IsZero() {
+			continue
+		}
+
+		b.executeJob(job, builtin, due, false)
+	}
+}
+
+// executeJob выполняет задачу, если запуск на это время еще не был выполнен.
+// Ручной запуск (/jobs run) только записывается в историю и не сдвигает расписание,
+// чтобы не пропустить следующий запуск по расписанию.
+func (b *Bot) executeJob(job models.ScheduledJob, builtin *builtinJob, scheduledFor time.Time, manual bool) error {
+	runID, claimed, err := b.db.ClaimJobRun(job.Name, scheduledFor)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		return err
+	}
+	if !claimed {
+		// Запуск уже выполнялся — например, до перезапуска бота
+		if manual {
+			return nil
+		}
+		if err := b.db.SetJobScheduledFor(job.Name, scheduledFor); err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+		return nil
+	}
+
+	log.Printf("⏰ Запуск задачи %s (%s)", job.Name, scheduledFor.Format("2006-01-02 15:04"))
+	runErr := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("паника: %v", r)
+			}
+		}()
+		return builtin.Run(b, job, scheduledFor)
+	}()
+	if runErr != nil {
+		log.Printf("⚠️ Задача %s завершилась с ошибкой: %v", job.Name, runErr)
+	}
+
+	if err := b.db.FinishJobRun(runID, runErr); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	if manual {
+		return runErr
+	}
+	if err := b.db.SetJobScheduledFor(job.Name, scheduledFor); err != nil {
+		log.Printf("⚠️ %v", err)
+	}
+	return runErr
+}
+
+// runDailyExport отправляет выгрузку заказов, созданных за день запуска.
+// В параметрах задачи можно указать формат, подъезд и статус, как в /export.
+func (b *Bot) runDailyExport(job models.ScheduledJob, scheduledFor time.Time) error {
+	recipients := b.staffRecipients(NotifyDailyReport)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	req, err := parseExportArgs(i18n.Default, job.Params, scheduledFor)
+	if err != nil {
+		return fmt.Errorf("некорректные параметры задачи: %v", err)
+	}
+	day := clock.StartOfDay(scheduledFor)
+	req.Filter.From = day
+	req.Filter.To = day.AddDate(0, 0, 1)
+	req.Filter.OnlyCurrent = false
+
+	file, caption, err := b.buildExport(req)
+	if err != nil {
+		return err
+	}
+	for _, chatID := range recipients {
+		doc := tgbotapi.NewDocument(chatID, file)
+		doc.Caption = "Ежедневный отчет. " + caption
+		if _, err := b.api.Send(doc); err != nil {
+			log.Printf("⚠️ Ошибка отправки ежедневного отчета: %v", err)
+		}
+	}
+	return nil
+}
+
+// runWeeklyRevenue отправляет статистику за последние 7 полных дней с графиками
+func (b *Bot) runWeeklyRevenue(job models.ScheduledJob, scheduledFor time.Time) error {
+	recipients := b.staffRecipients(NotifyWeeklyReport)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	to := clock.StartOfDay(scheduledFor)
+	from := to.AddDate(0, 0, -7)
+
+	current, err := b.db.GetOrderStats(from, to)
+	if err != nil {
+		return err
+	}
+	previous, err := b.db.GetOrderStats(from.AddDate(0, 0, -7), from)
+	if err != nil {
+		return err
+	}
+
+	text := "Еженедельная сводка\n\n" + formatStats(current, previous)
+	for _, chatID := range recipients {
+		b.sendMessage(chatID, text)
+	}
+	b.sendStatsCharts(current, recipients...)
+	return nil
+}
+
+// runWorkPlan отправляет мойщикам список квартир с заказами, подтвержденными после
+// предыдущего запуска по расписанию (при первом запуске — за последние сутки)
+func (b *Bot) runWorkPlan(job models.ScheduledJob, scheduledFor time.Time) error {
+	recipients := b.staffRecipients(NotifyWorkPlan)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	since := job.LastScheduledFor
+	if since.IsZero() {
+		since = scheduledFor.AddDate(0, 0, -1)
+	}
+	orders, err := b.db.GetWorkPlanOrders(since, scheduledFor)
+	if err != nil {
+		return err
+	}
+
+	tomorrow := scheduledFor.AddDate(0, 0, 1)
+	text := formatWorkPlan(orders, since.In(clock.Location()), tomorrow)
+	for _, chatID := range recipients {
+		for _, part := range splitMessage(text, 4000) {
+			b.sendMessage(chatID, part)
+		}
+	}
+	return nil
+}
+
+// formatWorkPlan формирует план работ по заказам, подтвержденным с since, сгруппированный по подъездам
+func formatWorkPlan(orders []models.Order, since, day time.Time) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🧽 План работ на %s\n", day.Format("02.01.2006")))
+	text.WriteString(fmt.Sprintf("Заказы, подтвержденные с %s\n", since.Format("02.01.2006 15:04")))
+	if len(orders) == 0 {
+		text.WriteString("\nНовых подтвержденных заказов нет.")
+		return text.String()
+	}
+	text.WriteString(fmt.Sprintf("Квартир: %d\n", len(orders)))
+
+	entrance := 0
+	for _, order := range orders {
+		if order.Entrance != entrance {
+			entrance = order.Entrance
+			text.WriteString(fmt.Sprintf("\nПодъезд %d\n", entrance))
+		}
+		text.WriteString(fmt.Sprintf("- эт. %d, кв. %s: %s\n", order.Floor, order.Apartment, orderWorkSummary(order)))
+		text.WriteString("  " + customerContact(order) + "\n")
+		if notes := accessNotes(i18n.Default, order); len(notes) > 0 {
+			text.WriteString("  🔑 " + strings.Join(notes, "; ") + "\n")
+		}
+		if order.Comment != "" {
+			text.WriteString("  💬 " + order.Comment + "\n")
+		}
+	}
+	return text.String()
+}
+
+// orderWorkSummary кратко описывает объем работ по заказу
+func orderWorkSummary(order models.Order) string {
+	var parts []string
+	for _, item := range order.Items {
+		parts = append(parts, fmt.Sprintf("%s × %d", itemTitleByCode(i18n.Default, item.ItemType), item.Quantity))
+	}
+	for _, loggia := range order.Loggias {
+		parts = append(parts, "лоджия ("+loggiaTitle(i18n.Default, loggia)+")")
+	}
+	for _, addon := range order.Addons {
+		parts = append(parts, strings.ToLower(addonTitleByCode(i18n.Default, addon.AddonType)))
+	}
+	if order.Description != "" {
+		parts = append(parts, "нестандартные окна: "+order.Description)
+	}
+	if len(parts) == 0 {
+		return "нет окон"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// handleJobs обрабатывает команду /jobs:
+//
+//	/jobs                          — список задач
+//	/jobs set <задача> <cron>      — изменить расписание
+//	/jobs params <задача> <...>    — изменить параметры
+//	/jobs on|off <задача>          — включить или выключить
+//	/jobs run <задача>             — запустить сейчас
+//	/jobs history <задача>         — последние запуски
+func (b *Bot) handleJobs(msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	if !b.requirePermission(chatID, PermManageJobs) {
+		return
+	}
+
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.sendJobsList(chatID)
+		return
+	}
+	if len(args) < 2 {
+		b.sendMessage(chatID, jobsUsage())
+		return
+	}
+
+	action, name := args[0], args[1]
+	builtin := findBuiltinJob(name)
+	if builtin == nil {
+		b.sendMessage(chatID, "Неизвестная задача «"+name+"».")
+		return
+	}
+	job, err := b.getJob(name)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		b.sendMessage(chatID, "Не удалось получить задачу.")
+		return
+	}
+
+	switch action {
+	case "set":
+		expr := strings.Join(args[2:], " ")
+		if _, err := cron.Parse(expr); err != nil {
+			b.sendMessage(chatID, "Некорректное расписание: "+err.Error())
+			return
+		}
+		job.Cron = expr
+	case "params":
+		job.Params = strings.Join(args[2:], " ")
+		if _, err := parseExportArgs(b.lang(chatID), job.Params, clock.Now()); job.Name == "daily_export" && err != nil {
+			b.sendMessage(chatID, "Некорректные параметры: "+err.Error())
+			return
+		}
+	case "on":
+		job.Enabled = true
+	case "off":
+		job.Enabled = false
+	case "run":
+		b.sendMessage(chatID, "Запускаю задачу "+name+"...")
+		if err := b.executeJob(job, builtin, clock.Now().Truncate(time.Second), true); err != nil {
+			b.sendMessage(chatID, "Задача завершилась с ошибкой: "+err.Error())
+			return
+		}
+		b.sendMessage(chatID, "Задача выполнена.")
+		return
+	case "history":
+		b.sendJobHistory(chatID, name)
+		return
+	default:
+		b.sendMessage(chatID, jobsUsage())
+		return
+	}
+
+	if err := b.db.UpdateJob(job); err != nil {
+		log.Printf("⚠️ %v", err)
+		b.sendMessage(chatID, "Не удалось сохранить задачу.")
+		return
+	}
+	b.sendMessage(chatID, "Задача "+name+" обновлена.")
+}
+
+func (b *Bot) getJob(name string) (models.ScheduledJob, error) {
+	jobs, err := b.db.GetJobs()
+	if err != nil {
+		return models.ScheduledJob{}, err
+	}
+	for _, job := range jobs {
+		if job.Name == name {
+			return job, nil
+		}
+	}
+	return models.ScheduledJob{}, fmt.Errorf("задача %s не найдена", name)
+}
+
+func (b *Bot) sendJobsList(chatID int64) {
+	jobs, err := b.db.GetJobs()
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		b.sendMessage(chatID, "Не удалось получить список задач.")
+		return
+	}
+
+	now := clock.Now()
+	var text strings.Builder
+	text.WriteString("Задачи планировщика:\n")
+	for _, job := range jobs {
+		description := ""
+		if builtin := findBuiltinJob(job.Name); builtin != nil {
+			description = " — " + builtin.Description
+		}
+		state := "включена"
+		if !job.Enabled {
+			state = "выключена"
+		}
+		text.WriteString(fmt.Sprintf("\n%s%s\nРасписание: %s (%s)\n", job.Name, description, job.Cron, state))
+		if job.Params != "" {
+			text.WriteString("Параметры: " + job.Params + "\n")
+		}
+		if schedule, err := cron.Parse(job.Cron); err == nil && job.Enabled {
+			text.WriteString("Следующий запуск: " + schedule.Next(now).Format("02.01.2006 15:04") + "\n")
+		}
+	}
+	text.WriteString("\n" + jobsUsage())
+
+	b.sendMessage(chatID, text.String())
+}
+
+func (b *Bot) sendJobHistory(chatID int64, name string) {
+	runs, err := b.db.GetJobRuns(name, 10)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		b.sendMessage(chatID, "Не удалось получить историю задачи.")
+		return
+	}
+	if len(runs) == 0 {
+		b.sendMessage(chatID, "Задача "+name+" еще не запускалась.")
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString("Последние запуски " + name + ":\n\n")
+	for _, run := range runs {
+		status := "✅"
+		switch run.Status {
+		case models.JobRunFailed:
+			status = "❌"
+		case models.JobRunRunning:
+			status = "⏳"
+		}
+		text.WriteString(fmt.Sprintf("%s %s", status, run.ScheduledFor.In(clock.Location()).Format("02.01.2006 15:04")))
+		if run.Error != "" {
+			text.WriteString(" — " + run.Error)
+		}
+		text.WriteString("\n")
+	}
+	b.sendMessage(chatID, text.String())
+}
+
+func jobsUsage() string {
+	return "Использование:\n" +
+		"/jobs set <задача> <cron> — например, /jobs set daily_export 0 21 * * *\n" +
+		"/jobs params <задача> <параметры> — например, /jobs params daily_export format=csv\n" +
+		"/jobs on|off <задача>\n" +
+		"/jobs run <задача>\n" +
+		"/jobs history <задача>"
+}
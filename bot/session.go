@@ -1,9 +1,11 @@
 package bot
 
 import (
+	"github.com/eugenepelipets/window-wash-bot/i18n"
 	"github.com/eugenepelipets/window-wash-bot/models"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"strconv"
+	"strings"
 )
 
 type UserSession struct {
@@ -11,6 +13,9 @@ type UserSession struct {
 	PreviousStates []string // История состояний для реализации "Назад"
 	Order          models.Order
 	TempData       map[string]interface{} // Для временных данных
+	CardMessageID  int                    // сообщение с карточкой заказа, которое меняется по ходу диалога
+	Token          string                 // идентификатор сессии в данных кнопок карточки
+	Step           int                    // номер показа карточки; кнопки прошлых показов устарели
 }
 
 var userSessions = make(map[int64]*UserSession)
@@ -36,10 +41,22 @@ func (b *Bot) updateState(chatID int64, newState string) {
 func (b *Bot) handleBack(chatID int64) {
 	session := b.getSession(chatID)
 	if len(session.PreviousStates) == 0 {
-		b.sendMainMenu(chatID)
+		b.showMainMenuCard(chatID)
 		return
 	}
 
+	// С первого вопроса о лоджии возвращаемся к предыдущей лоджии (или к их количеству),
+	// поэтому незаполненная лоджия убирается из заказа
+	if session.CurrentState == StateBalconyType && len(session.Order.Loggias) > 0 {
+		session.Order.Loggias = session.Order.Loggias[:len(session.Order.Loggias)-1]
+	}
+	// В режиме «разные» каждый тип окон — отдельный показ шага, назад ведет к предыдущему типу
+	if session.CurrentState == StateWindowsDifferent {
+		if index, _ := session.TempData[itemIndexKey].(int); index > 0 {
+			session.TempData[itemIndexKey] = index - 1
+		}
+	}
+
 	// Извлекаем предыдущее состояние
 	prevState := session.PreviousStates[len(session.PreviousStates)-1]
 	session.PreviousStates = session.PreviousStates[:len(session.PreviousStates)-1]
@@ -50,40 +67,54 @@ func (b *Bot) handleBack(chatID int64) {
 }
 
 func (b *Bot) restorePreviousStep(chatID int64, state string) {
+	lang := b.lang(chatID)
 	switch state {
+	case StateCustomerName:
+		b.showStep(chatID, i18n.T(lang, "phone_order.enter_name"))
+	case StateCustomerPhone:
+		b.showStep(chatID, i18n.T(lang, "phone_order.enter_phone"))
 	case StateWaitingForEntrance:
-		b.sendMessage(chatID, "Выберите подъезд:", createEntranceKeyboard())
+		b.showStep(chatID, i18n.T(lang, "order.choose_entrance"), createEntranceKeyboard(lang))
 	case StateWaitingForFloor:
-		b.sendMessage(chatID, "Введите этаж (1-24):")
+		b.showStep(chatID, i18n.T(lang, "order.enter_floor", maxFloor))
 	case StateWaitingForApartment:
-		b.sendMessage(chatID, "Введите номер квартиры:")
+		b.showStep(chatID, i18n.T(lang, "order.enter_apartment", maxApartment))
 	case StateWindowsSameOrDifferent:
-		b.sendMessage(chatID, "Количество створок на окнах одинаковое или разное?",
-			createWindowsSameOrDifferentKeyboard())
+		b.showStep(chatID, i18n.T(lang, "order.windows_same_or_different"),
+			b.windowsModeKeyboard(chatID))
 	case StateWindowsSameType:
-		b.sendMessage(chatID, "Выберите количество створок на окнах:",
-			createWindowTypesKeyboard())
-	case StateWindowsSameCount:
-		b.sendMessage(chatID, "Сколько всего окон?",
-			createWindowCountKeyboard())
-	case StateWindowsDifferent3:
-		b.sendMessage(chatID, "Сколько 3-створчатых окон? (0-6)",
-			createWindowCountKeyboard())
-	case StateWindowsDifferent4:
-		b.sendMessage(chatID, "Сколько 4-створчатых окон? (0-6)",
-			createWindowCountKeyboard())
-	case StateWindowsDifferent5:
-		b.sendMessage(chatID, "Сколько 5-створчатых окон? (0-6)",
-			createWindowCountKeyboard())
-	case StateWindowsDifferent6_7:
-		b.sendMessage(chatID, "Сколько 6-7-створчатых окон? (0-6)",
-			createWindowCountKeyboard())
+		b.showStep(chatID, i18n.T(lang, "order.choose_window_sash"),
+			createWindowTypesKeyboard(lang))
+	case StateWindowsSameCount, StateWindowsDifferent:
+		b.askWindowCount(chatID, createWindowCountKeyboard(lang))
 	case StateBalconyNeeded:
-		b.sendMessage(chatID, "Нужно ли мыть окна на лоджии?",
-			createBalconyNeededKeyboard())
-		//todo остальные состояния дописать
+		b.showStep(chatID, i18n.T(lang, "order.balcony_needed"),
+			createBalconyNeededKeyboard(lang))
+	case StateBalconyType:
+		b.askLoggia(chatID, "order.balcony_type", createBalconyTypeKeyboard(lang))
+	case StateBalconySash:
+		b.askLoggia(chatID, "order.balcony_sash", createBalconySashKeyboard(lang))
+	case StateBalconyGlazing:
+		b.askLoggia(chatID, "order.balcony_glazing", createBalconyGlazingKeyboard(lang))
+	case StateAddons:
+		b.showAddonsStep(chatID)
+	case StateTelegramNick:
+		b.sendNickRequest(chatID)
+	case StateContactPhone:
+		b.sendContactRequest(chatID)
+	case StateOrderNotes:
+		b.showNotesStep(chatID, "")
+	case StateIntercomCode:
+		b.showStep(chatID, i18n.T(lang, "order.intercom_enter", maxIntercomCodeLength),
+			tgbotapi.NewInlineKeyboardMarkup(backButtonRow(lang)))
+	case StateQuoteDetails:
+		b.showQuoteStep(chatID, "")
+	case StateWaitingConfirmation:
+		// Клиент передумал менять поле — возвращаемся к подтверждению
+		delete(b.getSession(chatID).TempData, editingKey)
+		b.showPricedConfirmation(chatID)
 	default:
-		b.sendMainMenu(chatID)
+		b.showMainMenuCard(chatID)
 	}
 }
 
@@ -92,37 +123,65 @@ func (b *Bot) handleTextMessage(msg *tgbotapi.Message) {
 	text := msg.Text
 
 	session := b.getSession(chatID)
+	// Ответ клиента отправлен под карточкой — следующий шаг показываем в новой
+	b.supersedeCard(chatID)
 
 	switch session.CurrentState {
 	case StateWaitingForFloor:
 		floor, err := strconv.Atoi(text)
-		if err != nil || floor < 1 || floor > 24 {
-			b.sendMessage(chatID, "Некорректный этаж. Введите цифру от 1 до 24:")
+		if err != nil || floor < 1 || floor > maxFloor {
+			b.showStep(chatID, b.t(chatID, "order.invalid_floor", maxFloor))
 			return
 		}
 		session.Order.Floor = floor
 		b.updateState(chatID, StateWaitingForApartment)
-		b.sendMessage(chatID, "Введите номер квартиры (1-1500):")
+		b.showStep(chatID, b.t(chatID, "order.enter_apartment", maxApartment))
 
 	case StateWaitingForApartment:
 		if !IsDigitsOnly(text) {
-			b.sendMessage(chatID, "Некорректный номер квартиры. Введите только цифры:")
+			b.showStep(chatID, b.t(chatID, "order.invalid_apartment_digits"))
 			return
 		}
 		apartment, err := strconv.Atoi(text)
-		if err != nil || apartment < 1 || apartment > 1500 {
-			b.sendMessage(chatID, "Некорректный номер квартиры. Введите цифру от 1 до 1500:")
+		if err != nil || apartment < 1 || apartment > maxApartment {
+			b.showStep(chatID, b.t(chatID, "order.invalid_apartment", maxApartment))
 			return
 		}
 		session.Order.Apartment = text
-		b.updateState(chatID, StateWindowsSameOrDifferent)
-		b.sendMessage(chatID, "Количество створок на окнах одинаковое или разное?",
-			createWindowsSameOrDifferentKeyboard())
+		b.continueOrder(chatID, func() {
+			b.updateState(chatID, StateWindowsSameOrDifferent)
+			b.showStep(chatID, b.t(chatID, "order.windows_same_or_different"),
+				b.windowsModeKeyboard(chatID))
+		})
+
+	case StateWindowsSameCount, StateWindowsDifferent:
+		count, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || count < 0 || count > maxWindowCount() {
+			b.showStep(chatID, b.t(chatID, "order.invalid_window_count", maxWindowCount()),
+				createWindowCountKeyboard(b.lang(chatID)))
+			return
+		}
+		b.handleWindowCount(chatID, count)
 
 	case StateTelegramNick:
-		b.handleTelegramNick(chatID, text)
+		b.handleTelegramNickInput(chatID, text)
+
+	case StateCustomerName:
+		b.handleCustomerName(chatID, text)
+
+	case StateCustomerPhone:
+		b.handleCustomerPhone(chatID, text)
+
+	case StateContactPhone:
+		b.handleContactPhone(msg)
+
+	case StateOrderNotes:
+		b.handleNotesText(chatID, text)
+
+	case StateIntercomCode:
+		b.handleIntercomCode(chatID, text)
 
 	default:
-		b.sendMessage(chatID, "Пожалуйста, используйте кнопки для продолжения.")
+		b.sendMessage(chatID, b.t(chatID, "use_buttons"))
 	}
 }
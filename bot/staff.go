@@ -0,0 +1,250 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Права доступа к административным командам
+const (
+	PermExport       = "export"
+	PermStats        = "stats"
+	PermFindOrders   = "find_orders"
+	PermManageOrders = "manage_orders"
+	PermBroadcast    = "broadcast"
+	PermCreateOrders = "create_orders"
+	PermImportOrders = "import_orders"
+	PermManageJobs   = "manage_jobs"
+	PermManageStaff  = "manage_staff"
+)
+
+// Типы уведомлений для сотрудников
+const (
+	NotifyDuplicateOrder = "duplicate_order"
+	NotifyDailyReport    = "daily_report"
+	NotifyWeeklyReport   = "weekly_report"
+	NotifyWorkPlan       = "work_plan"
+	NotifyQuoteRequest   = "quote_request"
+)
+
+// rolePermissions описывает, какие команды доступны каждой роли.
+// Владельцу доступно всё, поэтому он здесь не перечисляется.
+var rolePermissions = map[string][]string{
+	models.RoleDispatcher: {PermExport, PermStats, PermFindOrders, PermManageOrders, PermBroadcast, PermCreateOrders, PermImportOrders},
+	models.RoleAccountant: {PermExport, PermStats, PermFindOrders},
+	models.RoleWasher:     {},
+}
+
+// notificationRoutes описывает, каким ролям отправляются уведомления каждого типа
+var notificationRoutes = map[string][]string{
+	NotifyDuplicateOrder: {models.RoleOwner, models.RoleDispatcher},
+	NotifyDailyReport:    {models.RoleOwner, models.RoleAccountant},
+	NotifyWeeklyReport:   {models.RoleOwner, models.RoleAccountant},
+	NotifyWorkPlan:       {models.RoleWasher, models.RoleDispatcher},
+	NotifyQuoteRequest:   {models.RoleOwner, models.RoleDispatcher},
+}
+
+var roleTitles = map[string]string{
+	models.RoleOwner:      "владелец",
+	models.RoleDispatcher: "диспетчер",
+	models.RoleWasher:     "мойщик",
+	models.RoleAccountant: "бухгалтер",
+}
+
+// envOwnerID возвращает ID владельца из ADMIN_TELEGRAM_ID (0, если не задан).
+// Он всегда считается владельцем, даже если его нет в таблице staff.
+func envOwnerID() int64 {
+	ownerID, _ := strconv.ParseInt(os.Getenv("ADMIN_TELEGRAM_ID"), 10, 64)
+	return ownerID
+}
+
+// getRole возвращает роль пользователя ("" для обычных клиентов)
+func (b *Bot) getRole(chatID int64) string {
+	if ownerID := envOwnerID(); ownerID != 0 && chatID == ownerID {
+		return models.RoleOwner
+	}
+
+	staff, err := b.db.GetStaff(chatID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка проверки роли: %v", err)
+		return ""
+	}
+	if staff == nil {
+		return ""
+	}
+	return staff.Role
+}
+
+// hasPermission проверяет, есть ли у пользователя указанное право
+func (b *Bot) hasPermission(chatID int64, perm string) bool {
+	role := b.getRole(chatID)
+	if role == "" {
+		return false
+	}
+	if role == models.RoleOwner {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// requirePermission проверяет право и сообщает пользователю об отказе
+func (b *Bot) requirePermission(chatID int64, perm string) bool {
+	if b.hasPermission(chatID, perm) {
+		return true
+	}
+	b.sendMessage(chatID, "У вас нет прав для выполнения этой команды.")
+	return false
+}
+
+// notifyStaff отправляет уведомление всем сотрудникам, подписанным на этот тип по роли
+func (b *Bot) notifyStaff(notification string, text string) {
+	for _, chatID := range b.staffRecipients(notification) {
+		b.sendMessage(chatID, text)
+	}
+}
+
+// staffRecipients возвращает Telegram ID сотрудников, которым положено уведомление
+func (b *Bot) staffRecipients(notification string) []int64 {
+	roles := notificationRoutes[notification]
+	if len(roles) == 0 {
+		return nil
+	}
+
+	var recipients []int64
+	seen := make(map[int64]bool)
+	add := func(chatID int64) {
+		if !seen[chatID] {
+			seen[chatID] = true
+			recipients = append(recipients, chatID)
+		}
+	}
+
+	for _, role := range roles {
+		if role == models.RoleOwner {
+			if ownerID := envOwnerID(); ownerID != 0 {
+				add(ownerID)
+			}
+		}
+	}
+
+	staff, err := b.db.GetStaffByRoles(roles...)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения получателей уведомления: %v", err)
+	}
+	for _, s := range staff {
+		add(s.TelegramID)
+	}
+
+	return recipients
+}
+
+// handleStaff обрабатывает команду /staff:
+//
+//	/staff                     — список сотрудников
+//	/staff add <id> <роль>     — добавить сотрудника или сменить роль
+//	/staff remove <id>         — удалить сотрудника
+func (b *Bot) handleStaff(msg *tgbotapi.Message) {
+	if !b.requirePermission(msg.Chat.ID, PermManageStaff) {
+		return
+	}
+
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		b.sendStaffList(msg.Chat.ID)
+		return
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) != 3 {
+			b.sendMessage(msg.Chat.ID, staffUsage())
+			return
+		}
+		telegramID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || telegramID <= 0 {
+			b.sendMessage(msg.Chat.ID, "Некорректный Telegram ID.")
+			return
+		}
+		role := strings.ToLower(args[2])
+		if !models.IsValidRole(role) {
+			b.sendMessage(msg.Chat.ID, "Неизвестная роль. Доступные роли: owner, dispatcher, washer, accountant.")
+			return
+		}
+
+		staff := models.Staff{TelegramID: telegramID, Role: role, AddedBy: msg.Chat.ID}
+		if err := b.db.SaveStaff(staff); err != nil {
+			log.Printf("⚠️ Ошибка сохранения сотрудника: %v", err)
+			b.sendMessage(msg.Chat.ID, "Не удалось сохранить сотрудника.")
+			return
+		}
+		b.sendMessage(msg.Chat.ID, fmt.Sprintf("Сотрудник %d добавлен с ролью «%s».", telegramID, roleTitles[role]))
+
+	case "remove":
+		if len(args) != 2 {
+			b.sendMessage(msg.Chat.ID, staffUsage())
+			return
+		}
+		telegramID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			b.sendMessage(msg.Chat.ID, "Некорректный Telegram ID.")
+			return
+		}
+		if telegramID == envOwnerID() {
+			b.sendMessage(msg.Chat.ID, "Владельца из ADMIN_TELEGRAM_ID нельзя удалить командой.")
+			return
+		}
+
+		removed, err := b.db.DeleteStaff(telegramID)
+		if err != nil {
+			log.Printf("⚠️ Ошибка удаления сотрудника: %v", err)
+			b.sendMessage(msg.Chat.ID, "Не удалось удалить сотрудника.")
+			return
+		}
+		if !removed {
+			b.sendMessage(msg.Chat.ID, "Сотрудник не найден.")
+			return
+		}
+		b.sendMessage(msg.Chat.ID, fmt.Sprintf("Сотрудник %d удален.", telegramID))
+
+	default:
+		b.sendMessage(msg.Chat.ID, staffUsage())
+	}
+}
+
+func (b *Bot) sendStaffList(chatID int64) {
+	staff, err := b.db.GetStaffByRoles()
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения сотрудников: %v", err)
+		b.sendMessage(chatID, "Не удалось получить список сотрудников.")
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString("Сотрудники:\n\n")
+	if ownerID := envOwnerID(); ownerID != 0 {
+		text.WriteString(fmt.Sprintf("- %d — владелец (ADMIN_TELEGRAM_ID)\n", ownerID))
+	}
+	for _, s := range staff {
+		text.WriteString(fmt.Sprintf("- %d — %s\n", s.TelegramID, roleTitles[s.Role]))
+	}
+	text.WriteString("\n" + staffUsage())
+
+	b.sendMessage(chatID, text.String())
+}
+
+func staffUsage() string {
+	return "Использование:\n" +
+		"/staff add <Telegram ID> <owner|dispatcher|washer|accountant>\n" +
+		"/staff remove <Telegram ID>"
+}
@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/clock"
+	"github.com/eugenepelipets/window-wash-bot/i18n"
+	"github.com/eugenepelipets/window-wash-bot/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleStats обрабатывает команду /stats [today|week|month|year|YYYY-MM-DD [YYYY-MM-DD]]
+func (b *Bot) handleStats(msg *tgbotapi.Message) {
+	if !b.requirePermission(msg.Chat.ID, PermStats) {
+		return
+	}
+
+	from, to, err := parseStatsPeriod(msg.CommandArguments(), clock.Now())
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, err.Error()+"\n\n"+statsUsage())
+		return
+	}
+
+	current, err := b.db.GetOrderStats(from, to)
+	if err != nil {
+		log.Printf("⚠️ Ошибка расчета статистики: %v", err)
+		b.sendMessage(msg.Chat.ID, "Произошла ошибка при расчете статистики.")
+		return
+	}
+
+	// Предыдущий период такой же длины
+	previous, err := b.db.GetOrderStats(from.Add(-to.Sub(from)), from)
+	if err != nil {
+		log.Printf("⚠️ Ошибка расчета статистики за предыдущий период: %v", err)
+		b.sendMessage(msg.Chat.ID, "Произошла ошибка при расчете статистики.")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, formatStats(current, previous))
+	b.sendStatsCharts(current, msg.Chat.ID)
+}
+
+// parseStatsPeriod разбирает аргументы /stats и возвращает интервал [from, to)
+func parseStatsPeriod(args string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	fields := strings.Fields(strings.ToLower(args))
+
+	if len(fields) == 0 {
+		return today.AddDate(0, 0, -6), today.AddDate(0, 0, 1), nil
+	}
+
+	switch fields[0] {
+	case "today", "день", "сегодня":
+		return today, today.AddDate(0, 0, 1), nil
+	case "week", "неделя":
+		return today.AddDate(0, 0, -6), today.AddDate(0, 0, 1), nil
+	case "month", "месяц":
+		return today.AddDate(0, -1, 1), today.AddDate(0, 0, 1), nil
+	case "year", "год":
+		return today.AddDate(-1, 0, 1), today.AddDate(0, 0, 1), nil
+	}
+
+	from, err := time.ParseInLocation("2006-01-02", fields[0], now.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Не удалось разобрать период «%s».", fields[0])
+	}
+	to := from
+	if len(fields) > 1 {
+		to, err = time.ParseInLocation("2006-01-02", fields[1], now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("Не удалось разобрать дату «%s».", fields[1])
+		}
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("Дата окончания раньше даты начала.")
+	}
+
+	// Дата окончания включается в период
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func formatStats(current, previous models.OrderStats) string {
+	var text strings.Builder
+
+	lastDay := current.To.AddDate(0, 0, -1)
+	text.WriteString(fmt.Sprintf("📊 Статистика за %s — %s\n\n",
+		current.From.Format("02.01.2006"), lastDay.Format("02.01.2006")))
+
+	text.WriteString(fmt.Sprintf("Заказов: %d%s\n", current.TotalOrders,
+		formatChange(current.TotalOrders, previous.TotalOrders)))
+	text.WriteString(fmt.Sprintf("Выручка: %d руб.%s\n", current.Revenue,
+		formatChange(current.Revenue, previous.Revenue)))
+	text.WriteString(fmt.Sprintf("Средний чек: %d руб.%s\n", current.AverageCheck,
+		formatChange(current.AverageCheck, previous.AverageCheck)))
+
+	if len(current.ByStatus) > 0 {
+		text.WriteString("\nПо статусам:\n")
+		statuses := make([]string, 0, len(current.ByStatus))
+		for status := range current.ByStatus {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			text.WriteString(fmt.Sprintf("- %s: %d\n", i18n.T(i18n.Default, "status."+status), current.ByStatus[status]))
+		}
+	}
+
+	text.WriteString("\nОкна по типам:\n")
+	for _, item := range catalogItems() {
+		text.WriteString(fmt.Sprintf("- %s: %d\n", itemTitle(i18n.Default, item), current.ItemCounts[item.Code]))
+	}
+
+	loggiaShare := 0
+	if current.TotalOrders > 0 {
+		loggiaShare = current.OrdersWithLoggia * 100 / current.TotalOrders
+	}
+	text.WriteString(fmt.Sprintf("\nЛоджии: %d заказов (%d%%), всего лоджий: %d\n",
+		current.OrdersWithLoggia, loggiaShare, current.LoggiaCount))
+
+	if len(current.ByEntrance) > 0 {
+		text.WriteString("\nПо подъездам:\n")
+		entrances := make([]int, 0, len(current.ByEntrance))
+		for entrance := range current.ByEntrance {
+			entrances = append(entrances, entrance)
+		}
+		sort.Ints(entrances)
+		for _, entrance := range entrances {
+			text.WriteString(fmt.Sprintf("- Подъезд %d: %d заказов, %d руб.\n",
+				entrance, current.ByEntrance[entrance], current.RevenueByEntrance[entrance]))
+		}
+	}
+
+	if len(current.TopFloors) > 0 {
+		text.WriteString("\nТоп этажей:\n")
+		for _, fc := range current.TopFloors {
+			text.WriteString(fmt.Sprintf("- %d этаж: %d заказов\n", fc.Floor, fc.Orders))
+		}
+	}
+
+	return text.String()
+}
+
+// formatChange возвращает изменение относительно предыдущего периода, например " (+15%)"
+func formatChange(current, previous int) string {
+	if previous == 0 {
+		if current == 0 {
+			return ""
+		}
+		return " (новое)"
+	}
+	change := (current - previous) * 100 / previous
+	return fmt.Sprintf(" (%+d%%)", change)
+}
+
+func statsUsage() string {
+	return "Использование:\n" +
+		"/stats — последние 7 дней\n" +
+		"/stats today|week|month|year\n" +
+		"/stats 2026-04-01 2026-04-30"
+}
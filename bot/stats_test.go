@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStatsPeriod(t *testing.T) {
+	now := time.Date(2026, 4, 15, 14, 30, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		args     string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{args: "", wantFrom: date(4, 9), wantTo: date(4, 16)},
+		{args: "today", wantFrom: date(4, 15), wantTo: date(4, 16)},
+		{args: "Сегодня", wantFrom: date(4, 15), wantTo: date(4, 16)},
+		{args: "week", wantFrom: date(4, 9), wantTo: date(4, 16)},
+		{args: "месяц", wantFrom: date(3, 16), wantTo: date(4, 16)},
+		{args: "year", wantFrom: time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC), wantTo: date(4, 16)},
+		{args: "2026-04-01", wantFrom: date(4, 1), wantTo: date(4, 2)},
+		{args: "2026-04-01 2026-04-10", wantFrom: date(4, 1), wantTo: date(4, 11)},
+		{args: "2026-04-10 2026-04-10", wantFrom: date(4, 10), wantTo: date(4, 11)},
+		{args: "2026-04-10 2026-04-01", wantErr: true},
+		{args: "вчера", wantErr: true},
+		{args: "2026-04-01 завтра", wantErr: true},
+		{args: "01.04.2026", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			from, to, err := parseStatsPeriod(tt.args, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatsPeriod(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("parseStatsPeriod(%q) = [%v, %v), want [%v, %v)", tt.args, from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
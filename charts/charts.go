@@ -0,0 +1,330 @@
+// Package charts рисует простые PNG-графики для отчетов администраторам.
+// Все рендерится локально средствами Go, без внешних сервисов.
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Bar — одно значение на графике
+type Bar struct {
+	Label string
+	Value int
+}
+
+const (
+	width   = 800
+	height  = 500
+	padding = 50
+)
+
+var (
+	background = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	axisColor  = color.RGBA{R: 90, G: 90, B: 90, A: 255}
+	gridColor  = color.RGBA{R: 225, G: 225, B: 225, A: 255}
+	textColor  = color.RGBA{R: 30, G: 30, B: 30, A: 255}
+
+	// Палитра для столбцов
+	palette = []color.RGBA{
+		{R: 66, G: 133, B: 244, A: 255},
+		{R: 219, G: 68, B: 55, A: 255},
+		{R: 244, G: 180, B: 0, A: 255},
+		{R: 15, G: 157, B: 88, A: 255},
+		{R: 171, G: 71, B: 188, A: 255},
+		{R: 0, G: 172, B: 193, A: 255},
+	}
+)
+
+// Разобранный шрифт можно использовать из нескольких горутин, а font.Face — нет:
+// начертания создаются заново для каждого графика (отчеты строятся и по команде,
+// и планировщиком)
+var (
+	fontOnce sync.Once
+	fontErr  error
+	goFont   *opentype.Font
+)
+
+// loadFont загружает встроенный шрифт Go (в нем есть кириллица)
+func loadFont() (*opentype.Font, error) {
+	fontOnce.Do(func() {
+		goFont, fontErr = opentype.Parse(goregular.TTF)
+		if fontErr != nil {
+			fontErr = fmt.Errorf("ошибка загрузки шрифта: %v", fontErr)
+		}
+	})
+	return goFont, fontErr
+}
+
+// newFace создает начертание шрифта заданного размера
+func newFace(parsed *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания шрифта: %v", err)
+	}
+	return face, nil
+}
+
+// BarChart рисует вертикальную столбчатую диаграмму
+func BarChart(title string, bars []Bar) ([]byte, error) {
+	img, labelFace, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+	defer labelFace.Close()
+
+	left, top, right, bottom := padding+20, padding+10, width-padding, height-padding-20
+	maxValue := drawValueAxis(img, labelFace, bars, left, top, right, bottom)
+
+	if len(bars) > 0 {
+		slot := (right - left) / len(bars)
+		barWidth := slot * 2 / 3
+		for i, bar := range bars {
+			x := left + i*slot + (slot-barWidth)/2
+			barHeight := scale(bar.Value, maxValue, bottom-top)
+			fillRect(img, x, bottom-barHeight, x+barWidth, bottom, palette[i%len(palette)])
+
+			value := fmt.Sprint(bar.Value)
+			drawText(img, labelFace, value, x+(barWidth-textWidth(labelFace, value))/2, bottom-barHeight-4)
+			drawText(img, labelFace, bar.Label, x+(barWidth-textWidth(labelFace, bar.Label))/2, bottom+16)
+		}
+	}
+
+	return encode(img)
+}
+
+// HorizontalBarChart рисует горизонтальные столбцы (удобно для воронки и распределений)
+func HorizontalBarChart(title string, bars []Bar) ([]byte, error) {
+	img, labelFace, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+	defer labelFace.Close()
+
+	labelWidth := 0
+	for _, bar := range bars {
+		if w := textWidth(labelFace, bar.Label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	left, top, right, bottom := padding+labelWidth+10, padding+10, width-padding-40, height-padding
+	maxValue := 0
+	for _, bar := range bars {
+		if bar.Value > maxValue {
+			maxValue = bar.Value
+		}
+	}
+
+	if len(bars) > 0 {
+		slot := (bottom - top) / len(bars)
+		barHeight := slot * 2 / 3
+		for i, bar := range bars {
+			y := top + i*slot + (slot-barHeight)/2
+			barWidth := scale(bar.Value, maxValue, right-left)
+			fillRect(img, left, y, left+barWidth, y+barHeight, palette[i%len(palette)])
+
+			textY := y + barHeight/2 + 5
+			drawText(img, labelFace, bar.Label, left-10-textWidth(labelFace, bar.Label), textY)
+			drawText(img, labelFace, fmt.Sprint(bar.Value), left+barWidth+6, textY)
+		}
+	}
+	drawLine(img, left, top, left, bottom, axisColor)
+
+	return encode(img)
+}
+
+// LineChart рисует линейный график (например, заказы по дням)
+func LineChart(title string, points []Bar) ([]byte, error) {
+	img, labelFace, err := newCanvas(title)
+	if err != nil {
+		return nil, err
+	}
+	defer labelFace.Close()
+
+	left, top, right, bottom := padding+20, padding+10, width-padding, height-padding-20
+	maxValue := drawValueAxis(img, labelFace, points, left, top, right, bottom)
+
+	if len(points) > 0 {
+		// Отступаем от осей, чтобы крайние точки не сливались с ними
+		const inset = 15
+		step := 0
+		if len(points) > 1 {
+			step = (right - left - 2*inset) / (len(points) - 1)
+		}
+		// Подписи по оси X прореживаем, чтобы они не налезали друг на друга
+		labelEvery := 1
+		if len(points) > 10 {
+			labelEvery = (len(points) + 9) / 10
+		}
+
+		prevX, prevY := 0, 0
+		for i, point := range points {
+			x := left + inset + i*step
+			y := bottom - scale(point.Value, maxValue, bottom-top)
+			if i > 0 {
+				drawThickLine(img, prevX, prevY, x, y, palette[0])
+			}
+			fillRect(img, x-3, y-3, x+4, y+4, palette[0])
+			if i%labelEvery == 0 {
+				drawText(img, labelFace, point.Label, x-textWidth(labelFace, point.Label)/2, bottom+16)
+			}
+			prevX, prevY = x, y
+		}
+	}
+
+	return encode(img)
+}
+
+// newCanvas создает изображение с заголовком и возвращает начертание для подписей
+func newCanvas(title string) (*image.RGBA, font.Face, error) {
+	parsed, err := loadFont()
+	if err != nil {
+		return nil, nil, err
+	}
+	titleFace, err := newFace(parsed, 20)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer titleFace.Close()
+	labelFace, err := newFace(parsed, 13)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+	drawText(img, titleFace, title, (width-textWidth(titleFace, title))/2, padding-15)
+	return img, labelFace, nil
+}
+
+// drawValueAxis рисует оси и сетку по значениям, возвращает максимум шкалы
+func drawValueAxis(img *image.RGBA, labelFace font.Face, bars []Bar, left, top, right, bottom int) int {
+	maxValue := 0
+	for _, bar := range bars {
+		if bar.Value > maxValue {
+			maxValue = bar.Value
+		}
+	}
+	maxValue = niceCeil(maxValue)
+
+	const gridLines = 5
+	for i := 0; i <= gridLines; i++ {
+		y := bottom - (bottom-top)*i/gridLines
+		if i > 0 {
+			drawLine(img, left, y, right, y, gridColor)
+		}
+		label := fmt.Sprint(maxValue * i / gridLines)
+		drawText(img, labelFace, label, left-8-textWidth(labelFace, label), y+5)
+	}
+	drawLine(img, left, top, left, bottom, axisColor)
+	drawLine(img, left, bottom, right, bottom, axisColor)
+
+	return maxValue
+}
+
+// niceCeil округляет максимум шкалы вверх до "круглого" числа
+func niceCeil(value int) int {
+	if value <= 5 {
+		return 5
+	}
+	magnitude := 1
+	for magnitude*10 < value {
+		magnitude *= 10
+	}
+	for _, m := range []int{1, 2, 5, 10} {
+		if magnitude*m >= value {
+			return magnitude * m
+		}
+	}
+	return magnitude * 10
+}
+
+func scale(value, maxValue, size int) int {
+	if maxValue <= 0 || value <= 0 {
+		return 0
+	}
+	return value * size / maxValue
+}
+
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// drawLine рисует линию алгоритмом Брезенхэма
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		img.SetRGBA(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func drawThickLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	for d := -1; d <= 1; d++ {
+		drawLine(img, x0, y0+d, x1, y1+d, c)
+		drawLine(img, x0+d, y0, x1+d, y1, c)
+	}
+}
+
+func drawText(img *image.RGBA, face font.Face, text string, x, y int) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: textColor},
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+func textWidth(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("ошибка кодирования PNG: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
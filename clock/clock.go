@@ -0,0 +1,55 @@
+// Package clock хранит часовой пояс развертывания. В нем показываются даты клиентам
+// и сотрудникам, формируются имена файлов выгрузок, считаются периоды статистики
+// и расписание автоматических задач.
+package clock
+
+import (
+	"fmt"
+	"os"
+	"time"
+	_ "time/tzdata" // база часовых поясов встроена в бинарник для минимальных образов
+)
+
+// DefaultTimezone используется, если переменная TIMEZONE не задана
+const DefaultTimezone = "Europe/Istanbul"
+
+var location = mustLoad(DefaultTimezone)
+
+// Init загружает часовой пояс из переменной окружения TIMEZONE (например, Europe/Moscow).
+// Некорректное значение — ошибка конфигурации, молча переходить на UTC нельзя.
+func Init() error {
+	name := os.Getenv("TIMEZONE")
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("некорректный часовой пояс TIMEZONE=%q: %v", name, err)
+	}
+	location = loc
+	return nil
+}
+
+// Location возвращает часовой пояс развертывания
+func Location() *time.Location {
+	return location
+}
+
+// Now возвращает текущее время в часовом поясе развертывания
+func Now() time.Time {
+	return time.Now().In(location)
+}
+
+// StartOfDay возвращает начало суток, в которые попадает t, в часовом поясе развертывания
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
+
+func mustLoad(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
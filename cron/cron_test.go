@@ -0,0 +1,183 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{"* * * * *", false},
+		{"0 9 * * 1-5", false},
+		{"*/15 9-18/3 1,15 * 0", false},
+		{"0 0 * * 7", false},
+		{"@daily", false},
+		{"  @weekly  ", false},
+		{"", true},
+		{"0 9 * *", true},
+		{"0 9 * * * *", true},
+		{"60 * * * *", true},
+		{"0 24 * * *", true},
+		{"0 0 0 * *", true},
+		{"0 0 * 13 *", true},
+		{"0 0 * * 8", true},
+		{"5-1 * * * *", true},
+		{"*/0 * * * *", true},
+		{"*/x * * * *", true},
+		{"a * * * *", true},
+		{"@yearly", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "каждую минуту, секунды отбрасываются",
+			expr: "* * * * *",
+			from: time.Date(2026, 4, 1, 10, 0, 30, 0, utc),
+			want: time.Date(2026, 4, 1, 10, 1, 0, 0, utc),
+		},
+		{
+			name: "строго после текущего времени",
+			expr: "0 9 * * *",
+			from: time.Date(2026, 4, 1, 9, 0, 0, 0, utc),
+			want: time.Date(2026, 4, 2, 9, 0, 0, 0, utc),
+		},
+		{
+			name: "шаг по минутам",
+			expr: "*/15 * * * *",
+			from: time.Date(2026, 4, 1, 10, 16, 0, 0, utc),
+			want: time.Date(2026, 4, 1, 10, 30, 0, 0, utc),
+		},
+		{
+			name: "диапазон с шагом по часам",
+			expr: "0 9-18/3 * * *",
+			from: time.Date(2026, 4, 1, 12, 1, 0, 0, utc),
+			want: time.Date(2026, 4, 1, 15, 0, 0, 0, utc),
+		},
+		{
+			name: "только будни: с пятницы на понедельник",
+			expr: "0 8 * * 1-5",
+			from: time.Date(2026, 4, 3, 9, 0, 0, 0, utc), // пятница
+			want: time.Date(2026, 4, 6, 8, 0, 0, 0, utc),
+		},
+		{
+			name: "воскресенье как 7",
+			expr: "0 10 * * 7",
+			from: time.Date(2026, 4, 1, 0, 0, 0, 0, utc), // среда
+			want: time.Date(2026, 4, 5, 10, 0, 0, 0, utc),
+		},
+		{
+			name: "31-е число пропускает короткие месяцы",
+			expr: "0 0 31 * *",
+			from: time.Date(2026, 3, 31, 1, 0, 0, 0, utc),
+			want: time.Date(2026, 5, 31, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "29 февраля — в ближайший високосный год",
+			expr: "0 0 29 2 *",
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, utc),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "день месяца и день недели: достаточно любого",
+			expr: "0 0 15 * 1",
+			from: time.Date(2026, 4, 7, 0, 0, 0, 0, utc), // вторник
+			want: time.Date(2026, 4, 13, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "день месяца и день недели: день месяца раньше",
+			expr: "0 0 15 * 1",
+			from: time.Date(2026, 4, 14, 0, 0, 0, 0, utc),
+			want: time.Date(2026, 4, 15, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "ограничен только день недели",
+			expr: "0 0 * * 1",
+			from: time.Date(2026, 4, 14, 0, 0, 0, 0, utc),
+			want: time.Date(2026, 4, 20, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "@monthly",
+			expr: "@monthly",
+			from: time.Date(2026, 12, 15, 0, 0, 0, 0, utc),
+			want: time.Date(2027, 1, 1, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "переход на летнее время: несуществующий час пропускается",
+			expr: "30 2 * * *",
+			from: time.Date(2026, 3, 28, 3, 0, 0, 0, berlin),
+			want: time.Date(2026, 3, 30, 2, 30, 0, 0, berlin),
+		},
+		{
+			name: "переход на летнее время: следующий час не сдвигается",
+			expr: "0 3 * * *",
+			from: time.Date(2026, 3, 29, 1, 0, 0, 0, berlin),
+			want: time.Date(2026, 3, 29, 3, 0, 0, 0, berlin),
+		},
+		{
+			name: "переход на зимнее время: запуск в местное время",
+			expr: "0 9 * * *",
+			from: time.Date(2026, 10, 24, 10, 0, 0, 0, berlin),
+			want: time.Date(2026, 10, 25, 9, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+// Ежедневная задача в час, который повторяется при переходе на зимнее время, запускается один раз
+func TestNextRepeatedHour(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := s.Next(time.Date(2026, 10, 25, 0, 0, 0, 0, berlin))
+	if first.Hour() != 2 || first.Minute() != 30 || first.Day() != 25 {
+		t.Fatalf("first run = %v, want 2026-10-25 02:30", first)
+	}
+	second := s.Next(first)
+	want := time.Date(2026, 10, 26, 2, 30, 0, 0, berlin)
+	if !second.Equal(want) {
+		t.Errorf("second run = %v, want %v", second, want)
+	}
+}
@@ -0,0 +1,253 @@
+package i18n
+
+// en — сообщения на английском языке
+var en = catalog{
+	"language.name":    "English",
+	"language.choose":  "Choose a language:",
+	"language.changed": "Language changed: %s.",
+
+	"command.unknown":  "I don't understand this command 🤔",
+	"use_buttons":      "Please use the buttons to continue.",
+	"unknown_action":   "Unknown command",
+	"callback.stale":   "This button is out of date",
+	"callback.invalid": "Invalid value",
+	"main_menu":        "Main menu:",
+
+	"start.welcome": "Hi! To book a window cleaning, tap the button 👇",
+	"start.welcome_back": "Welcome back! Your last order: entrance %d, floor %d, apt. %s.\n" +
+		"You can repeat it or place a new one 👇",
+
+	"btn.new_order":           "New order",
+	"btn.repeat_order":        "🔁 Repeat last order",
+	"btn.back":                "Back",
+	"btn.quote_send":          "📨 Request a quote",
+	"btn.quote_accept":        "✅ Accept",
+	"btn.quote_decline":       "❌ Decline",
+	"btn.entrance":            "Entrance %d",
+	"btn.windows_same":        "Same",
+	"btn.windows_different":   "Different",
+	"btn.windows_custom":      "Non-standard windows (by photo)",
+	"btn.sash":                "%s-sash",
+	"btn.balcony_count#one":   "%d loggia",
+	"btn.balcony_count#other": "%d loggias",
+	"btn.more":                "➕ More",
+	"btn.balcony_none":        "Not needed",
+	"btn.balcony_standard":    "Standard",
+	"btn.balcony_floor":       "Floor-to-ceiling",
+	"btn.glazing_cold":        "❄️ Cold (aluminium)",
+	"btn.glazing_warm":        "🏠 Warm (PVC)",
+	"btn.glazing_unknown":     "Not sure",
+	"btn.addon_piece":         "%s — %d RUB each",
+	"btn.addon_order":         "%s — %d RUB",
+	"btn.addons_done":         "Done",
+	"btn.confirm":             "Confirm",
+	"btn.cancel":              "Cancel",
+	"btn.use_nick":            "Use @%s",
+	"btn.skip":                "Skip",
+	"btn.reply_back":          "⬅️ Back",
+	"btn.share_contact":       "📱 Share my number",
+	"btn.keep_phone":          "Keep %s",
+	"btn.edit_address":        "✏️ Address",
+	"btn.edit_windows":        "✏️ Windows",
+	"btn.edit_balcony":        "✏️ Loggias",
+	"btn.edit_addons":         "✏️ Extras",
+	"btn.edit_nick":           "✏️ Username",
+	"btn.edit_phone":          "✏️ Phone",
+	"btn.presence":            "I need to be at home",
+	"btn.time_morning":        "Morning",
+	"btn.time_afternoon":      "Afternoon",
+	"btn.time_evening":        "Evening",
+	"btn.intercom":            "🔢 Intercom code",
+	"btn.intercom_set":        "🔢 Intercom: %s",
+	"btn.comment_clear":       "🗑 Remove comment",
+	"btn.notes_done":          "Done",
+	"btn.edit_notes":          "✏️ Notes",
+
+	"order.choose_entrance":           "Choose your entrance:",
+	"order.enter_floor":               "Enter the floor number (1-%d):",
+	"order.invalid_floor":             "Invalid floor. Enter a number from 1 to %d:",
+	"order.enter_apartment":           "Enter the apartment number (1-%d):",
+	"order.invalid_apartment_digits":  "Invalid apartment number. Use digits only:",
+	"order.invalid_apartment":         "Invalid apartment number. Enter a number from 1 to %d:",
+	"order.windows_same_or_different": "Do all your windows have the same number of sashes?",
+	"order.choose_window_sash":        "Choose the number of sashes per window:",
+	"order.windows_total":             "How many windows in total? Tap a button or type a number (up to %d):",
+	"order.item_count":                "%s — how many? Tap a button or type a number (up to %d):",
+	"order.invalid_window_count":      "Invalid number of windows. Enter a number from 0 to %d:",
+	"order.empty":                     "The order has no windows or loggias — please add at least one.",
+	"order.balcony_needed":            "Should we clean the loggia windows too?",
+	"order.balcony_type":              "Are the loggia windows standard or floor-to-ceiling?",
+	"order.balcony_sash":              "Choose the number of sashes on the loggia:",
+	"order.balcony_glazing":           "What kind of glazing does the loggia have?",
+	"order.addons":                    "Need any extra services? Tick the ones you need and tap “Done”:",
+	"order.loggia_number":             "Loggia %d of %d.",
+	"order.nick_enter":                "Enter your Telegram username (or tap 'Skip'):",
+	"order.nick_profile":              "Your Telegram username: @%s.\nTap the button to use it, or enter another username:",
+	"order.nick_invalid": "Invalid username. A Telegram username is 5 to 32 characters: Latin letters, " +
+		"digits and \"_\", starting with a letter. Enter it again or tap 'Skip':",
+	"order.contact_request": "Leave a phone number so the cleaner can reach you.\n" +
+		"Tap the button below or type the number (e.g. +905301234567):",
+	"order.contact_saved":    "Contact number: %s\nKeep it or send another number with the button or as a message:",
+	"order.contact_invalid":  "Invalid phone number. Enter it as +905301234567 or tap \"%s\":",
+	"order.contact_foreign":  "This is someone else's contact. Share your own number with the \"%s\" button or type a number:",
+	"order.contact_phone":    "Contact number: %s",
+	"order.back_to_previous": "Going back to the previous step.",
+	"order.price_error":      "Failed to calculate the price. Please start over.",
+	"order.check_error":      "Failed to check existing orders. Please try again later.",
+	"order.save_error":       "Failed to save the order. Please try again later.",
+	"order.duplicate": "It looks like someone has already placed an order for this apartment.\n" +
+		"Your order is pending clarification. An administrator will contact you.",
+	"order.confirmed":    "Your order is confirmed! The cleaner will come to you.",
+	"order.canceled":     "Order canceled.",
+	"order.already_done": "This order has already been placed or canceled.",
+	"order.repeat_intro": "Repeating your order from %s. The price is calculated at current rates; you can change any section with the ✏️ buttons below the order.",
+	"order.repeat_error": "Failed to load your last order. Please try again later.",
+	"order.quote_intro": "Panoramic windows, stained glass and other non-standard windows are priced individually.\n" +
+		"Send photos of the windows (up to %d) and describe them: size, number, outside access. Then tap «Request a quote».",
+	"order.quote_photos":      "Photos: %d of %d",
+	"order.quote_description": "Description: %s",
+	"order.quote_incomplete":  "A quote needs at least one photo and a description of the windows.",
+	"order.quote_photo_only":  "Send a photo or a text description of the windows.",
+	"order.quote_too_many":    "You can attach no more than %d photos.",
+	"order.quote_sent":        "Quote request #%d has been sent. We will send the price to this chat.",
+	"order.notes": "Any notes for the cleaner? Set access details with the buttons and send a comment " +
+		"as a message (for example, «dog at home» or «call an hour ahead»). Everything is optional — tap «Done».",
+	"order.notes_comment":    "Comment: %s",
+	"order.intercom_enter":   "Enter the intercom code (up to %d characters) or «-» to remove it:",
+	"order.intercom_invalid": "The intercom code must be at most %d characters. Please enter it again:",
+	"order.comment_too_long": "The comment is too long — at most %d characters.",
+
+	"card.title":     "🧾 Your order",
+	"card.customer":  "Customer: %s",
+	"card.entrance":  "Entrance: %d",
+	"card.floor":     "Floor: %d",
+	"card.apartment": "Apartment: %s",
+	"card.item":      "%s: %d",
+	"card.loggia":    "Loggia %d: %s",
+	"card.addons":    "Extras: %s",
+	"card.nick":      "Username: @%s",
+	"card.phone":     "Phone: %s",
+
+	"confirm.title":          "Please confirm your order:",
+	"confirm.phone_customer": "Customer: %s\nPhone: %s",
+	"confirm.nick":           "Telegram username: @%s",
+	"confirm.contact_phone":  "Contact number: %s",
+	"confirm.address":        "Entrance: %d\nFloor: %d\nApartment: %s",
+	"confirm.windows":        "Windows:",
+	"confirm.item_line":      "- %s: %d * %d = %d RUB",
+	"confirm.loggias":        "Loggias:",
+	"confirm.loggia_line":    "- Loggia %d (%s): %d RUB",
+	"confirm.addons":         "Extra services:",
+	"confirm.addon_line":     "- %s: %d * %d = %d RUB",
+	"confirm.notes":          "Notes:",
+	"confirm.total":          "Total: %d RUB",
+
+	"loggia.standard":     "standard",
+	"loggia.floor":        "floor-to-ceiling",
+	"loggia.title":        "%s, %s sashes",
+	"loggia.glazing_cold": "cold glazing",
+	"loggia.glazing_warm": "warm glazing",
+
+	"notes.intercom": "intercom code %s",
+	"notes.presence": "customer must be at home",
+	"notes.time":     "preferred time: %s",
+	"notes.comment":  "comment: %s",
+
+	"time.morning":   "morning",
+	"time.afternoon": "afternoon",
+	"time.evening":   "evening",
+
+	"quote.offer":    "Cleaning price for request #%d: %d RUB.\nDo you accept?",
+	"quote.accepted": "Order #%d is confirmed! Please wait for the cleaner.",
+	"quote.declined": "Request #%d is canceled. If you change your mind, place a new order.",
+	"quote.outdated": "This offer is no longer valid",
+
+	"phone_order.start":         "New order for a customer.\n\nEnter the customer's name:",
+	"phone_order.enter_name":    "Enter the customer's name:",
+	"phone_order.invalid_name":  "Invalid name. Enter the customer's name (up to 200 characters):",
+	"phone_order.enter_phone":   "Enter the customer's phone number (for example, +79001234567):",
+	"phone_order.invalid_phone": "Invalid phone number. Enter the number in the format +79001234567:",
+	"phone_order.confirmed":     "The customer's order is confirmed.",
+	"phone_order.duplicate":     "This apartment already has an order. The customer's order is pending clarification.",
+
+	"status.pending":             "pending",
+	"status.confirmed":           "confirmed",
+	"status.needs_clarification": "pending clarification",
+	"status.awaiting_quote":      "awaiting quote",
+	"status.canceled":            "canceled",
+
+	"common.yes": "yes",
+	"common.no":  "no",
+
+	"source.bot":    "bot",
+	"source.phone":  "phone",
+	"source.import": "import",
+
+	"notify.status_changed": "The status of your order #%d has changed: %s.",
+	"notify.admin_message":  "Message from the administrator about order #%d:\n\n%s",
+
+	"export.wizard":            "Order export\n\nFilters: %s\nFormat: %s\n\nChoose the options and press “Download”.",
+	"export.btn_period_all":    "All time",
+	"export.btn_period_today":  "Today",
+	"export.btn_period_week":   "7 days",
+	"export.btn_period_month":  "Month",
+	"export.btn_all_entrances": "All",
+	"export.btn_all_statuses":  "All statuses",
+	"export.btn_scope_current": "Current only",
+	"export.btn_scope_all":     "All orders, including outdated",
+	"export.btn_download":      "📥 Download",
+	"export.type_current":      "current only",
+	"export.type_all":          "all orders",
+	"export.filter_range":      "from %s to %s",
+	"export.filter_from":       "from %s",
+	"export.filter_to":         "until %s",
+	"export.filter_entrance":   "entrance %d",
+	"export.filter_status":     "status “%s”",
+	"export.caption":           "Order report (%s)",
+	"export.error":             "Error: %v",
+	"export.err_argument":      "unknown argument “%s”",
+	"export.err_date":          "invalid date “%s”, use the YYYY-MM-DD format",
+	"export.err_entrance":      "invalid entrance “%s”, allowed from 1 to %d",
+	"export.err_status":        "unknown status “%s”",
+	"export.err_format":        "unknown format “%s”, available: csv, xlsx, json and ndjson",
+	"export.err_lang":          "unknown language “%s”, available: %s",
+	"export.err_scope":         "scope must be current or all",
+	"export.err_status_scope":  "orders with status \"%s\" are never current: drop current or export all orders",
+	"export.err_param":         "unknown parameter “%s”",
+	"export.err_range":         "the end date is before the start date",
+	"export.usage": "Usage:\n" +
+		"/export — export wizard with buttons\n" +
+		"/export all xlsx\n" +
+		"/export json — for integrations, the schema is versioned\n" +
+		"/export from=2026-04-01 to=2026-04-30 entrance=3 status=confirmed format=xlsx\n\n" +
+		"Parameters: from, to (YYYY-MM-DD), entrance (1-%d), " +
+		"status (confirmed, needs_clarification, awaiting_quote, canceled), format (csv, xlsx, json, ndjson), scope (current, all), " +
+		"lang (ru, en, tr — CSV header language)",
+	"csv.id":                "ID",
+	"csv.created_at":        "Created at",
+	"csv.entrance":          "Entrance",
+	"csv.floor":             "Floor",
+	"csv.apartment":         "Apartment",
+	"csv.windows_same":      "Same sashes",
+	"csv.balcony_count":     "Loggias",
+	"csv.balcony_type":      "Loggia type",
+	"csv.balcony_sash":      "Loggia sashes",
+	"csv.loggias":           "Loggia details",
+	"csv.addons":            "Extra services",
+	"csv.description":       "Description",
+	"csv.comment":           "Comment",
+	"csv.intercom_code":     "Intercom code",
+	"csv.presence_required": "Presence required",
+	"csv.preferred_time":    "Preferred time",
+	"csv.telegram_nick":     "Telegram username",
+	"csv.price":             "Price",
+	"csv.status":            "Status",
+	"csv.is_current":        "Current",
+	"csv.user_id":           "User ID",
+	"csv.username":          "Username",
+	"csv.first_name":        "First name",
+	"csv.last_name":         "Last name",
+	"csv.source":            "Source",
+	"csv.customer_name":     "Customer name",
+	"csv.customer_phone":    "Customer phone",
+}
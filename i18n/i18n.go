@@ -0,0 +1,126 @@
+// Package i18n содержит каталоги сообщений бота (русский, английский, турецкий)
+// и выбирает формы множественного числа по правилам каждого языка.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Поддерживаемые языки
+const (
+	Russian = "ru"
+	English = "en"
+	Turkish = "tr"
+
+	// Default — язык, на котором написаны все сообщения; используется, если перевода нет
+	Default = Russian
+)
+
+// Languages — поддерживаемые языки в порядке показа в /language
+var Languages = []string{Russian, English, Turkish}
+
+// catalog — сообщения одного языка. Шаблоны используют глаголы fmt.
+// Формы множественного числа хранятся под ключами "ключ#one", "ключ#few", "ключ#many", "ключ#other".
+type catalog map[string]string
+
+var catalogs = map[string]catalog{
+	Russian: ru,
+	English: en,
+	Turkish: tr,
+}
+
+// IsSupported проверяет, есть ли каталог для языка
+func IsSupported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Detect выбирает язык по коду языка клиента Telegram (например, "en-US").
+// Пользователям из русскоязычных стран показывается русский, остальным — английский.
+func Detect(languageCode string) string {
+	code := strings.ToLower(languageCode)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+
+	switch {
+	case code == "":
+		return Default
+	case IsSupported(code):
+		return code
+	case code == "uk" || code == "be" || code == "kk":
+		return Russian
+	default:
+		return English
+	}
+}
+
+// T возвращает сообщение на языке lang, подставляя args в шаблон
+func T(lang, key string, args ...interface{}) string {
+	return format(lookup(lang, key), args)
+}
+
+// N возвращает сообщение в форме множественного числа для n.
+// В шаблон первым аргументом подставляется n, за ним — args.
+func N(lang, key string, n int, args ...interface{}) string {
+	// Без каталога сообщение берется из языка по умолчанию — и формы тоже по его правилам
+	if !IsSupported(lang) {
+		lang = Default
+	}
+	form := pluralForm(lang, n)
+	template, ok := find(lang, key+"#"+form)
+	if !ok {
+		template = lookup(lang, key+"#other")
+	}
+	return format(template, append([]interface{}{n}, args...))
+}
+
+// lookup ищет сообщение в каталоге языка, затем в каталоге по умолчанию
+func lookup(lang, key string) string {
+	if message, ok := find(lang, key); ok {
+		return message
+	}
+	return key
+}
+
+func find(lang, key string) (string, bool) {
+	if message, ok := catalogs[lang][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[Default][key]
+	return message, ok
+}
+
+func format(template string, args []interface{}) string {
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// pluralForm возвращает форму множественного числа по правилам CLDR
+func pluralForm(lang string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case Russian:
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case English:
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	default:
+		// В турецком после числительного существительное стоит в единственном числе
+		return "other"
+	}
+}
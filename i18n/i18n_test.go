@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{Russian, 0, "many"},
+		{Russian, 1, "one"},
+		{Russian, 2, "few"},
+		{Russian, 4, "few"},
+		{Russian, 5, "many"},
+		{Russian, 11, "many"},
+		{Russian, 12, "many"},
+		{Russian, 14, "many"},
+		{Russian, 21, "one"},
+		{Russian, 22, "few"},
+		{Russian, 25, "many"},
+		{Russian, 101, "one"},
+		{Russian, 111, "many"},
+		{Russian, 112, "many"},
+		{Russian, 1004, "few"},
+		{Russian, -1, "one"},
+		{Russian, -3, "few"},
+		{English, 0, "other"},
+		{English, 1, "one"},
+		{English, 2, "other"},
+		{English, 21, "other"},
+		{Turkish, 1, "other"},
+		{Turkish, 5, "other"},
+	}
+
+	for _, tt := range tests {
+		if got := pluralForm(tt.lang, tt.n); got != tt.want {
+			t.Errorf("pluralForm(%s, %d) = %s, want %s", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{Russian, 1, "1 лоджия"},
+		{Russian, 3, "3 лоджии"},
+		{Russian, 5, "5 лоджий"},
+		{Russian, 21, "21 лоджия"},
+		{English, 1, "1 loggia"},
+		{English, 2, "2 loggias"},
+		{Turkish, 2, "2 balkon"},
+		// Для неизвестного языка используется каталог по умолчанию
+		{"de", 2, "2 лоджии"},
+	}
+
+	for _, tt := range tests {
+		if got := N(tt.lang, "btn.balcony_count", tt.n); got != tt.want {
+			t.Errorf("N(%s, %d) = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTFallback(t *testing.T) {
+	if got := T(English, "no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key = %q, want the key itself", got)
+	}
+	if got := T("de", "status.confirmed"); got != ru["status.confirmed"] {
+		t.Errorf("unknown language = %q, want %q", got, ru["status.confirmed"])
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"", Russian},
+		{"ru", Russian},
+		{"en-US", English},
+		{"tr_TR", Turkish},
+		{"UK", Russian},
+		{"kk", Russian},
+		{"de", English},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(tt.code); got != tt.want {
+			t.Errorf("Detect(%q) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// Каждое сообщение русского каталога должно быть переведено; формы множественного
+// числа сверяются по ключу без суффикса
+func TestCatalogsComplete(t *testing.T) {
+	base := func(c catalog) map[string]bool {
+		keys := make(map[string]bool, len(c))
+		for key := range c {
+			key, _, _ = strings.Cut(key, "#")
+			keys[key] = true
+		}
+		return keys
+	}
+
+	want := base(ru)
+	for _, lang := range Languages {
+		got := base(catalogs[lang])
+		for key := range want {
+			if !got[key] {
+				t.Errorf("%s: нет перевода %s", lang, key)
+			}
+		}
+		for key := range got {
+			if !want[key] {
+				t.Errorf("%s: лишний ключ %s", lang, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package i18n
+
+// ru — сообщения на русском языке; этот каталог полный, остальные переводятся с него
+var ru = catalog{
+	"language.name":    "Русский",
+	"language.choose":  "Выберите язык:",
+	"language.changed": "Язык изменен: %s.",
+
+	"command.unknown":  "Я не понимаю эту команду 🤔",
+	"use_buttons":      "Пожалуйста, используйте кнопки для продолжения.",
+	"unknown_action":   "Неизвестная команда",
+	"callback.stale":   "Эта кнопка устарела",
+	"callback.invalid": "Некорректное значение",
+	"main_menu":        "Главное меню:",
+
+	"start.welcome": "Привет! Чтобы записаться на мойку окон нажми на кнопку 👇",
+	"start.welcome_back": "С возвращением! Прошлый заказ: подъезд %d, этаж %d, кв. %s.\n" +
+		"Можно повторить его или оформить новый 👇",
+
+	"btn.new_order":          "Новый заказ",
+	"btn.repeat_order":       "🔁 Повторить прошлый заказ",
+	"btn.back":               "Назад",
+	"btn.quote_send":         "📨 Отправить на расчет",
+	"btn.quote_accept":       "✅ Принять",
+	"btn.quote_decline":      "❌ Отказаться",
+	"btn.entrance":           "Подъезд %d",
+	"btn.windows_same":       "Одинаковые",
+	"btn.windows_different":  "Разные",
+	"btn.windows_custom":     "Нестандартные окна (по фото)",
+	"btn.sash":               "%s-створчатые",
+	"btn.balcony_count#one":  "%d лоджия",
+	"btn.balcony_count#few":  "%d лоджии",
+	"btn.balcony_count#many": "%d лоджий",
+	"btn.more":               "➕ Больше",
+	"btn.balcony_none":       "Не нужно",
+	"btn.balcony_standard":   "Стандартные",
+	"btn.balcony_floor":      "До пола",
+	"btn.glazing_cold":       "❄️ Холодное (алюминий)",
+	"btn.glazing_warm":       "🏠 Теплое (пластик)",
+	"btn.glazing_unknown":    "Не знаю",
+	"btn.addon_piece":        "%s — %d руб./шт.",
+	"btn.addon_order":        "%s — %d руб.",
+	"btn.addons_done":        "Готово",
+	"btn.confirm":            "Подтвердить",
+	"btn.cancel":             "Отменить",
+	"btn.use_nick":           "Указать @%s",
+	"btn.skip":               "Пропустить",
+	"btn.reply_back":         "⬅️ Назад",
+	"btn.share_contact":      "📱 Отправить мой номер",
+	"btn.keep_phone":         "Оставить %s",
+	"btn.edit_address":       "✏️ Адрес",
+	"btn.edit_windows":       "✏️ Окна",
+	"btn.edit_balcony":       "✏️ Лоджии",
+	"btn.edit_addons":        "✏️ Доп. услуги",
+	"btn.edit_nick":          "✏️ Ник",
+	"btn.edit_phone":         "✏️ Телефон",
+	"btn.presence":           "Нужно мое присутствие",
+	"btn.time_morning":       "Утро",
+	"btn.time_afternoon":     "День",
+	"btn.time_evening":       "Вечер",
+	"btn.intercom":           "🔢 Код домофона",
+	"btn.intercom_set":       "🔢 Домофон: %s",
+	"btn.comment_clear":      "🗑 Удалить комментарий",
+	"btn.notes_done":         "Готово",
+	"btn.edit_notes":         "✏️ Пожелания",
+
+	"order.choose_entrance":           "Выберите подъезд:",
+	"order.enter_floor":               "Введите номер этажа (1-%d):",
+	"order.invalid_floor":             "Некорректный этаж. Введите цифру от 1 до %d:",
+	"order.enter_apartment":           "Введите номер квартиры (1-%d):",
+	"order.invalid_apartment_digits":  "Некорректный номер квартиры. Введите только цифры:",
+	"order.invalid_apartment":         "Некорректный номер квартиры. Введите цифру от 1 до %d:",
+	"order.windows_same_or_different": "Количество створок на окнах одинаковое или разное?",
+	"order.choose_window_sash":        "Выберите количество створок на окнах:",
+	"order.windows_total":             "Сколько всего окон? Нажмите кнопку или введите число (до %d):",
+	"order.item_count":                "%s — сколько штук? Нажмите кнопку или введите число (до %d):",
+	"order.invalid_window_count":      "Некорректное количество окон. Введите число от 0 до %d:",
+	"order.empty":                     "В заказе нет ни одного окна или лоджии — добавьте хотя бы одно.",
+	"order.balcony_needed":            "Нужно ли мыть окна на лоджии?",
+	"order.balcony_type":              "Окна на лоджии стандартные или до пола?",
+	"order.balcony_sash":              "Выберите количество створок на лоджии:",
+	"order.balcony_glazing":           "Какое остекление на лоджии?",
+	"order.addons":                    "Нужны ли дополнительные услуги? Отметьте нужные и нажмите «Готово»:",
+	"order.loggia_number":             "Лоджия %d из %d.",
+	"order.nick_enter":                "Введите ваш ник в Telegram (или нажмите 'Пропустить'):",
+	"order.nick_profile":              "Ваш ник в Telegram: @%s.\nНажмите кнопку, чтобы указать его, или введите другой ник:",
+	"order.nick_invalid": "Некорректный ник. Ник в Telegram — от 5 до 32 символов: латинские буквы, " +
+		"цифры и «_», начинается с буквы. Введите ник еще раз или нажмите 'Пропустить':",
+	"order.contact_request": "Оставьте номер телефона, чтобы мастер мог связаться с вами.\n" +
+		"Нажмите кнопку ниже или введите номер вручную (например, +79001234567):",
+	"order.contact_saved":    "Номер для связи: %s\nОставьте его или отправьте другой номер кнопкой либо сообщением:",
+	"order.contact_invalid":  "Некорректный номер телефона. Введите номер в формате +79001234567 или нажмите кнопку «%s»:",
+	"order.contact_foreign":  "Это чужой контакт. Отправьте свой номер кнопкой «%s» или введите номер сообщением:",
+	"order.contact_phone":    "Номер для связи: %s",
+	"order.back_to_previous": "Возвращаемся к предыдущему шагу.",
+	"order.price_error":      "Ошибка расчета стоимости. Пожалуйста, начните заново.",
+	"order.check_error":      "Ошибка проверки заказов. Попробуйте позже.",
+	"order.save_error":       "Ошибка сохранения заказа. Пожалуйста, попробуйте позже.",
+	"order.duplicate": "Похоже, кто-то уже создал заявку для этой квартиры.\n" +
+		"Ваш заказ поставлен на уточнение. Администратор свяжется с вами.",
+	"order.confirmed":    "Ваш заказ подтвержден! Ожидайте мастера.",
+	"order.canceled":     "Заказ отменен.",
+	"order.already_done": "Этот заказ уже оформлен или отменен.",
+	"order.repeat_intro": "Повторяем заказ от %s. Стоимость рассчитана по текущим ценам, любые данные можно изменить кнопками ✏️ под заказом.",
+	"order.repeat_error": "Не удалось загрузить прошлый заказ. Попробуйте позже.",
+	"order.quote_intro": "Панорамные окна, витражи и другие нестандартные окна мы считаем индивидуально.\n" +
+		"Пришлите фото окон (до %d) и опишите их: размеры, количество, доступ снаружи. Затем нажмите «Отправить на расчет».",
+	"order.quote_photos":      "Фото: %d из %d",
+	"order.quote_description": "Описание: %s",
+	"order.quote_incomplete":  "Для расчета нужны хотя бы одно фото и описание окон.",
+	"order.quote_photo_only":  "Пришлите фото или текстовое описание окон.",
+	"order.quote_too_many":    "Можно приложить не больше %d фото.",
+	"order.quote_sent":        "Заявка №%d отправлена на расчет. Мы пришлем стоимость в этот чат.",
+	"order.notes": "Есть пожелания к мойке? Отметьте условия доступа кнопками, а комментарий " +
+		"(например, «собака дома» или «позвонить за час») отправьте сообщением. Все необязательно — нажмите «Готово».",
+	"order.notes_comment":    "Комментарий: %s",
+	"order.intercom_enter":   "Введите код домофона (до %d символов) или «-», чтобы удалить его:",
+	"order.intercom_invalid": "Код домофона должен быть не длиннее %d символов. Введите его еще раз:",
+	"order.comment_too_long": "Комментарий слишком длинный — не больше %d символов.",
+
+	"card.title":     "🧾 Ваш заказ",
+	"card.customer":  "Клиент: %s",
+	"card.entrance":  "Подъезд: %d",
+	"card.floor":     "Этаж: %d",
+	"card.apartment": "Квартира: %s",
+	"card.item":      "%s: %d",
+	"card.loggia":    "Лоджия %d: %s",
+	"card.addons":    "Доп. услуги: %s",
+	"card.nick":      "Ник: @%s",
+	"card.phone":     "Телефон: %s",
+
+	"confirm.title":          "Подтвердите заказ:",
+	"confirm.phone_customer": "Клиент: %s\nТелефон: %s",
+	"confirm.nick":           "Ник в Telegram: @%s",
+	"confirm.contact_phone":  "Телефон для связи: %s",
+	"confirm.address":        "Подъезд: %d\nЭтаж: %d\nКвартира: %s",
+	"confirm.windows":        "Окна:",
+	"confirm.item_line":      "- %s: %d * %d = %d руб.",
+	"confirm.loggias":        "Лоджии:",
+	"confirm.loggia_line":    "- Лоджия %d (%s): %d руб.",
+	"confirm.addons":         "Дополнительные услуги:",
+	"confirm.addon_line":     "- %s: %d * %d = %d руб.",
+	"confirm.notes":          "Пожелания:",
+	"confirm.total":          "Итого стоимость: %d руб.",
+
+	"loggia.standard":     "стандартные",
+	"loggia.floor":        "до пола",
+	"loggia.title":        "%s, %s створки",
+	"loggia.glazing_cold": "холодное остекление",
+	"loggia.glazing_warm": "теплое остекление",
+
+	"notes.intercom": "код домофона %s",
+	"notes.presence": "нужно присутствие клиента",
+	"notes.time":     "удобное время: %s",
+	"notes.comment":  "комментарий: %s",
+
+	"time.morning":   "утром",
+	"time.afternoon": "днем",
+	"time.evening":   "вечером",
+
+	"quote.offer":    "Стоимость мойки по заявке №%d: %d руб.\nПринимаете?",
+	"quote.accepted": "Заказ №%d подтвержден! Ожидайте мастера.",
+	"quote.declined": "Заявка №%d отменена. Если передумаете, оформите новый заказ.",
+	"quote.outdated": "Это предложение уже не действует",
+
+	"phone_order.start":         "Новый заказ для клиента.\n\nВведите имя клиента:",
+	"phone_order.enter_name":    "Введите имя клиента:",
+	"phone_order.invalid_name":  "Некорректное имя. Введите имя клиента (до 200 символов):",
+	"phone_order.enter_phone":   "Введите телефон клиента (например, +79001234567):",
+	"phone_order.invalid_phone": "Некорректный номер телефона. Введите номер в формате +79001234567:",
+	"phone_order.confirmed":     "Заказ клиента подтвержден.",
+	"phone_order.duplicate":     "Для этой квартиры уже есть заказ. Заказ клиента поставлен на уточнение.",
+
+	"status.pending":             "ожидает",
+	"status.confirmed":           "подтвержден",
+	"status.needs_clarification": "на уточнении",
+	"status.awaiting_quote":      "ждет расчета",
+	"status.canceled":            "отменен",
+
+	"common.yes": "да",
+	"common.no":  "нет",
+
+	"source.bot":    "бот",
+	"source.phone":  "телефон",
+	"source.import": "импорт",
+
+	"notify.status_changed": "Статус вашего заказа №%d изменен: %s.",
+	"notify.admin_message":  "Сообщение от администратора по заказу №%d:\n\n%s",
+
+	"export.wizard":            "Выгрузка заказов\n\nФильтры: %s\nФормат: %s\n\nВыберите параметры и нажмите «Скачать».",
+	"export.btn_period_all":    "Все время",
+	"export.btn_period_today":  "Сегодня",
+	"export.btn_period_week":   "7 дней",
+	"export.btn_period_month":  "Месяц",
+	"export.btn_all_entrances": "Все",
+	"export.btn_all_statuses":  "Все статусы",
+	"export.btn_scope_current": "Только актуальные",
+	"export.btn_scope_all":     "Все заказы, включая неактуальные",
+	"export.btn_download":      "📥 Скачать",
+	"export.type_current":      "только актуальные",
+	"export.type_all":          "все заказы",
+	"export.filter_range":      "с %s по %s",
+	"export.filter_from":       "с %s",
+	"export.filter_to":         "по %s",
+	"export.filter_entrance":   "подъезд %d",
+	"export.filter_status":     "статус «%s»",
+	"export.caption":           "Отчет по заказам (%s)",
+	"export.error":             "Ошибка: %v",
+	"export.err_argument":      "неизвестный аргумент «%s»",
+	"export.err_date":          "некорректная дата «%s», используйте формат ГГГГ-ММ-ДД",
+	"export.err_entrance":      "некорректный подъезд «%s», допустимо от 1 до %d",
+	"export.err_status":        "неизвестный статус «%s»",
+	"export.err_format":        "неизвестный формат «%s», доступны csv, xlsx, json и ndjson",
+	"export.err_lang":          "неизвестный язык «%s», доступны %s",
+	"export.err_scope":         "scope может быть current или all",
+	"export.err_status_scope":  "заказы со статусом «%s» не бывают актуальными: уберите current или выгрузите все заказы",
+	"export.err_param":         "неизвестный параметр «%s»",
+	"export.err_range":         "дата окончания раньше даты начала",
+	"export.usage": "Использование:\n" +
+		"/export — мастер выгрузки с кнопками\n" +
+		"/export all xlsx\n" +
+		"/export json — для интеграций, схема версионируется\n" +
+		"/export from=2026-04-01 to=2026-04-30 entrance=3 status=confirmed format=xlsx\n\n" +
+		"Параметры: from, to (ГГГГ-ММ-ДД), entrance (1-%d), " +
+		"status (confirmed, needs_clarification, awaiting_quote, canceled), format (csv, xlsx, json, ndjson), scope (current, all), " +
+		"lang (ru, en, tr — язык заголовков CSV)",
+	"csv.id":                "ID",
+	"csv.created_at":        "Дата создания",
+	"csv.entrance":          "Подъезд",
+	"csv.floor":             "Этаж",
+	"csv.apartment":         "Квартира",
+	"csv.windows_same":      "Одинаковые створки",
+	"csv.balcony_count":     "Лоджии",
+	"csv.balcony_type":      "Тип лоджии",
+	"csv.balcony_sash":      "Створки лоджии",
+	"csv.loggias":           "Состав лоджий",
+	"csv.addons":            "Доп. услуги",
+	"csv.description":       "Описание",
+	"csv.comment":           "Комментарий",
+	"csv.intercom_code":     "Код домофона",
+	"csv.presence_required": "Нужно присутствие",
+	"csv.preferred_time":    "Удобное время",
+	"csv.telegram_nick":     "Телеграм ник",
+	"csv.price":             "Стоимость",
+	"csv.status":            "Статус",
+	"csv.is_current":        "Актуальный",
+	"csv.user_id":           "ID пользователя",
+	"csv.username":          "Username",
+	"csv.first_name":        "Имя",
+	"csv.last_name":         "Фамилия",
+	"csv.source":            "Источник",
+	"csv.customer_name":     "Имя клиента",
+	"csv.customer_phone":    "Телефон клиента",
+}
@@ -0,0 +1,252 @@
+package i18n
+
+// tr — сообщения на турецком языке
+var tr = catalog{
+	"language.name":    "Türkçe",
+	"language.choose":  "Bir dil seçin:",
+	"language.changed": "Dil değiştirildi: %s.",
+
+	"command.unknown":  "Bu komutu anlamıyorum 🤔",
+	"use_buttons":      "Devam etmek için lütfen düğmeleri kullanın.",
+	"unknown_action":   "Bilinmeyen komut",
+	"callback.stale":   "Bu düğmenin süresi doldu",
+	"callback.invalid": "Geçersiz değer",
+	"main_menu":        "Ana menü:",
+
+	"start.welcome": "Merhaba! Cam temizliği randevusu için düğmeye basın 👇",
+	"start.welcome_back": "Tekrar hoş geldiniz! Son siparişiniz: %d. giriş, %d. kat, daire %s.\n" +
+		"Tekrarlayabilir veya yeni sipariş verebilirsiniz 👇",
+
+	"btn.new_order":           "Yeni sipariş",
+	"btn.repeat_order":        "🔁 Son siparişi tekrarla",
+	"btn.back":                "Geri",
+	"btn.quote_send":          "📨 Fiyat iste",
+	"btn.quote_accept":        "✅ Kabul et",
+	"btn.quote_decline":       "❌ Reddet",
+	"btn.entrance":            "Giriş %d",
+	"btn.windows_same":        "Aynı",
+	"btn.windows_different":   "Farklı",
+	"btn.windows_custom":      "Standart dışı pencereler (fotoğrafla)",
+	"btn.sash":                "%s kanatlı",
+	"btn.balcony_count#other": "%d balkon",
+	"btn.more":                "➕ Daha fazla",
+	"btn.balcony_none":        "Gerek yok",
+	"btn.balcony_standard":    "Standart",
+	"btn.balcony_floor":       "Yerden tavana",
+	"btn.glazing_cold":        "❄️ Soğuk (alüminyum)",
+	"btn.glazing_warm":        "🏠 Isı yalıtımlı (PVC)",
+	"btn.glazing_unknown":     "Bilmiyorum",
+	"btn.addon_piece":         "%s — adet başı %d RUB",
+	"btn.addon_order":         "%s — %d RUB",
+	"btn.addons_done":         "Tamam",
+	"btn.confirm":             "Onayla",
+	"btn.cancel":              "İptal et",
+	"btn.use_nick":            "@%s kullan",
+	"btn.skip":                "Atla",
+	"btn.reply_back":          "⬅️ Geri",
+	"btn.share_contact":       "📱 Numaramı gönder",
+	"btn.keep_phone":          "%s kalsın",
+	"btn.edit_address":        "✏️ Adres",
+	"btn.edit_windows":        "✏️ Pencereler",
+	"btn.edit_balcony":        "✏️ Balkonlar",
+	"btn.edit_addons":         "✏️ Ek hizmetler",
+	"btn.edit_nick":           "✏️ Kullanıcı adı",
+	"btn.edit_phone":          "✏️ Telefon",
+	"btn.presence":            "Evde olmam gerekiyor",
+	"btn.time_morning":        "Sabah",
+	"btn.time_afternoon":      "Öğlen",
+	"btn.time_evening":        "Akşam",
+	"btn.intercom":            "🔢 Diafon kodu",
+	"btn.intercom_set":        "🔢 Diafon: %s",
+	"btn.comment_clear":       "🗑 Yorumu sil",
+	"btn.notes_done":          "Tamam",
+	"btn.edit_notes":          "✏️ İstekler",
+
+	"order.choose_entrance":           "Girişinizi seçin:",
+	"order.enter_floor":               "Kat numarasını girin (1-%d):",
+	"order.invalid_floor":             "Geçersiz kat. 1 ile %d arasında bir sayı girin:",
+	"order.enter_apartment":           "Daire numarasını girin (1-%d):",
+	"order.invalid_apartment_digits":  "Geçersiz daire numarası. Yalnızca rakam girin:",
+	"order.invalid_apartment":         "Geçersiz daire numarası. 1 ile %d arasında bir sayı girin:",
+	"order.windows_same_or_different": "Pencerelerinizin kanat sayısı aynı mı, farklı mı?",
+	"order.choose_window_sash":        "Pencerelerin kanat sayısını seçin:",
+	"order.windows_total":             "Toplam kaç pencere var? Bir düğmeye basın veya sayı yazın (en fazla %d):",
+	"order.item_count":                "%s — kaç adet? Bir düğmeye basın veya sayı yazın (en fazla %d):",
+	"order.invalid_window_count":      "Geçersiz pencere sayısı. 0 ile %d arasında bir sayı girin:",
+	"order.empty":                     "Siparişte hiç pencere veya balkon yok — en az bir tane ekleyin.",
+	"order.balcony_needed":            "Balkon camları da temizlensin mi?",
+	"order.balcony_type":              "Balkon camları standart mı, yerden tavana mı?",
+	"order.balcony_sash":              "Balkondaki kanat sayısını seçin:",
+	"order.balcony_glazing":           "Balkonda nasıl bir cam sistemi var?",
+	"order.addons":                    "Ek hizmet ister misiniz? İstediklerinizi işaretleyip «Tamam» düğmesine basın:",
+	"order.loggia_number":             "Balkon %d / %d.",
+	"order.nick_enter":                "Telegram kullanıcı adınızı girin (veya 'Atla' düğmesine basın):",
+	"order.nick_profile":              "Telegram kullanıcı adınız: @%s.\nKullanmak için düğmeye basın veya başka bir kullanıcı adı girin:",
+	"order.nick_invalid": "Geçersiz kullanıcı adı. Telegram kullanıcı adı 5-32 karakterdir: Latin harfleri, " +
+		"rakamlar ve \"_\"; bir harfle başlar. Tekrar girin veya 'Atla' düğmesine basın:",
+	"order.contact_request": "Temizlikçinin size ulaşabilmesi için bir telefon numarası bırakın.\n" +
+		"Aşağıdaki düğmeye basın veya numarayı yazın (örneğin +905301234567):",
+	"order.contact_saved":    "İletişim numarası: %s\nBu numarayı bırakın veya düğmeyle ya da mesajla başka bir numara gönderin:",
+	"order.contact_invalid":  "Geçersiz telefon numarası. +905301234567 biçiminde girin veya \"%s\" düğmesine basın:",
+	"order.contact_foreign":  "Bu başka birinin kişi kartı. Kendi numaranızı \"%s\" düğmesiyle paylaşın veya numarayı yazın:",
+	"order.contact_phone":    "İletişim numarası: %s",
+	"order.back_to_previous": "Önceki adıma dönülüyor.",
+	"order.price_error":      "Fiyat hesaplanamadı. Lütfen baştan başlayın.",
+	"order.check_error":      "Siparişler kontrol edilemedi. Lütfen daha sonra tekrar deneyin.",
+	"order.save_error":       "Sipariş kaydedilemedi. Lütfen daha sonra tekrar deneyin.",
+	"order.duplicate": "Görünüşe göre bu daire için zaten bir sipariş verilmiş.\n" +
+		"Siparişiniz netleştirme bekliyor. Yönetici sizinle iletişime geçecek.",
+	"order.confirmed":    "Siparişiniz onaylandı! Temizlikçiyi bekleyin.",
+	"order.canceled":     "Sipariş iptal edildi.",
+	"order.already_done": "Bu sipariş zaten verildi veya iptal edildi.",
+	"order.repeat_intro": "%s tarihli siparişiniz tekrarlanıyor. Fiyat güncel tarifelere göre hesaplandı; her bölümü siparişin altındaki ✏️ düğmeleriyle değiştirebilirsiniz.",
+	"order.repeat_error": "Son siparişiniz yüklenemedi. Lütfen daha sonra tekrar deneyin.",
+	"order.quote_intro": "Panoramik pencereler, vitraylar ve diğer standart dışı pencereler ayrıca fiyatlandırılır.\n" +
+		"Pencerelerin fotoğraflarını (en fazla %d) gönderin ve tarif edin: boyut, adet, dışarıdan erişim. Ardından «Fiyat iste»ye basın.",
+	"order.quote_photos":      "Fotoğraf: %d / %d",
+	"order.quote_description": "Açıklama: %s",
+	"order.quote_incomplete":  "Fiyat için en az bir fotoğraf ve pencerelerin açıklaması gerekli.",
+	"order.quote_photo_only":  "Pencerelerin fotoğrafını veya yazılı açıklamasını gönderin.",
+	"order.quote_too_many":    "En fazla %d fotoğraf ekleyebilirsiniz.",
+	"order.quote_sent":        "%d numaralı fiyat talebi gönderildi. Fiyatı bu sohbete göndereceğiz.",
+	"order.notes": "Temizlik için istekleriniz var mı? Erişim bilgilerini butonlarla işaretleyin, yorumu ise " +
+		"mesaj olarak gönderin (örneğin «evde köpek var» veya «bir saat önce arayın»). Hepsi isteğe bağlı — «Tamam»a basın.",
+	"order.notes_comment":    "Yorum: %s",
+	"order.intercom_enter":   "Diafon kodunu girin (en fazla %d karakter) veya silmek için «-» gönderin:",
+	"order.intercom_invalid": "Diafon kodu en fazla %d karakter olmalı. Lütfen tekrar girin:",
+	"order.comment_too_long": "Yorum çok uzun — en fazla %d karakter.",
+
+	"card.title":     "🧾 Siparişiniz",
+	"card.customer":  "Müşteri: %s",
+	"card.entrance":  "Giriş: %d",
+	"card.floor":     "Kat: %d",
+	"card.apartment": "Daire: %s",
+	"card.item":      "%s: %d",
+	"card.loggia":    "Balkon %d: %s",
+	"card.addons":    "Ek hizmetler: %s",
+	"card.nick":      "Kullanıcı adı: @%s",
+	"card.phone":     "Telefon: %s",
+
+	"confirm.title":          "Siparişinizi onaylayın:",
+	"confirm.phone_customer": "Müşteri: %s\nTelefon: %s",
+	"confirm.nick":           "Telegram kullanıcı adı: @%s",
+	"confirm.contact_phone":  "İletişim numarası: %s",
+	"confirm.address":        "Giriş: %d\nKat: %d\nDaire: %s",
+	"confirm.windows":        "Pencereler:",
+	"confirm.item_line":      "- %s: %d * %d = %d RUB",
+	"confirm.loggias":        "Balkonlar:",
+	"confirm.loggia_line":    "- Balkon %d (%s): %d RUB",
+	"confirm.addons":         "Ek hizmetler:",
+	"confirm.addon_line":     "- %s: %d * %d = %d RUB",
+	"confirm.notes":          "İstekler:",
+	"confirm.total":          "Toplam: %d RUB",
+
+	"loggia.standard":     "standart",
+	"loggia.floor":        "yerden tavana",
+	"loggia.title":        "%s, %s kanat",
+	"loggia.glazing_cold": "soğuk cam",
+	"loggia.glazing_warm": "ısı yalıtımlı cam",
+
+	"notes.intercom": "diafon kodu %s",
+	"notes.presence": "müşterinin evde olması gerekiyor",
+	"notes.time":     "uygun zaman: %s",
+	"notes.comment":  "yorum: %s",
+
+	"time.morning":   "sabah",
+	"time.afternoon": "öğlen",
+	"time.evening":   "akşam",
+
+	"quote.offer":    "%d numaralı talep için temizlik fiyatı: %d RUB.\nKabul ediyor musunuz?",
+	"quote.accepted": "%d numaralı sipariş onaylandı! Temizlikçiyi bekleyin.",
+	"quote.declined": "%d numaralı talep iptal edildi. Fikrinizi değiştirirseniz yeni sipariş verin.",
+	"quote.outdated": "Bu teklif artık geçerli değil",
+
+	"phone_order.start":         "Müşteri için yeni sipariş.\n\nMüşterinin adını girin:",
+	"phone_order.enter_name":    "Müşterinin adını girin:",
+	"phone_order.invalid_name":  "Geçersiz ad. Müşterinin adını girin (en fazla 200 karakter):",
+	"phone_order.enter_phone":   "Müşterinin telefon numarasını girin (örneğin, +79001234567):",
+	"phone_order.invalid_phone": "Geçersiz telefon numarası. Numarayı +79001234567 biçiminde girin:",
+	"phone_order.confirmed":     "Müşterinin siparişi onaylandı.",
+	"phone_order.duplicate":     "Bu daire için zaten bir sipariş var. Müşterinin siparişi netleştirme bekliyor.",
+
+	"status.pending":             "bekliyor",
+	"status.confirmed":           "onaylandı",
+	"status.needs_clarification": "netleştirme bekliyor",
+	"status.awaiting_quote":      "fiyat bekleniyor",
+	"status.canceled":            "iptal edildi",
+
+	"common.yes": "evet",
+	"common.no":  "hayır",
+
+	"source.bot":    "bot",
+	"source.phone":  "telefon",
+	"source.import": "içe aktarma",
+
+	"notify.status_changed": "%d numaralı siparişinizin durumu değişti: %s.",
+	"notify.admin_message":  "%d numaralı sipariş hakkında yöneticiden mesaj:\n\n%s",
+
+	"export.wizard":            "Sipariş dışa aktarma\n\nFiltreler: %s\nBiçim: %s\n\nSeçenekleri belirleyip «İndir» düğmesine basın.",
+	"export.btn_period_all":    "Tüm zamanlar",
+	"export.btn_period_today":  "Bugün",
+	"export.btn_period_week":   "7 gün",
+	"export.btn_period_month":  "Ay",
+	"export.btn_all_entrances": "Tümü",
+	"export.btn_all_statuses":  "Tüm durumlar",
+	"export.btn_scope_current": "Yalnızca güncel",
+	"export.btn_scope_all":     "Güncel olmayanlar dahil tüm siparişler",
+	"export.btn_download":      "📥 İndir",
+	"export.type_current":      "yalnızca güncel",
+	"export.type_all":          "tüm siparişler",
+	"export.filter_range":      "%s - %s",
+	"export.filter_from":       "%s tarihinden itibaren",
+	"export.filter_to":         "%s tarihine kadar",
+	"export.filter_entrance":   "%d. giriş",
+	"export.filter_status":     "durum «%s»",
+	"export.caption":           "Sipariş raporu (%s)",
+	"export.error":             "Hata: %v",
+	"export.err_argument":      "bilinmeyen argüman «%s»",
+	"export.err_date":          "geçersiz tarih «%s», YYYY-AA-GG biçimini kullanın",
+	"export.err_entrance":      "geçersiz giriş «%s», 1 ile %d arasında olmalı",
+	"export.err_status":        "bilinmeyen durum «%s»",
+	"export.err_format":        "bilinmeyen biçim «%s», kullanılabilir: csv, xlsx, json ve ndjson",
+	"export.err_lang":          "bilinmeyen dil «%s», kullanılabilir: %s",
+	"export.err_scope":         "scope current veya all olmalı",
+	"export.err_status_scope":  "\"%s\" durumundaki siparişler hiçbir zaman güncel olmaz: current seçeneğini kaldırın veya tüm siparişleri dışa aktarın",
+	"export.err_param":         "bilinmeyen parametre «%s»",
+	"export.err_range":         "bitiş tarihi başlangıç tarihinden önce",
+	"export.usage": "Kullanım:\n" +
+		"/export — düğmeli dışa aktarma sihirbazı\n" +
+		"/export all xlsx\n" +
+		"/export json — entegrasyonlar için, şema sürümlüdür\n" +
+		"/export from=2026-04-01 to=2026-04-30 entrance=3 status=confirmed format=xlsx\n\n" +
+		"Parametreler: from, to (YYYY-AA-GG), entrance (1-%d), " +
+		"status (confirmed, needs_clarification, awaiting_quote, canceled), format (csv, xlsx, json, ndjson), scope (current, all), " +
+		"lang (ru, en, tr — CSV başlık dili)",
+	"csv.id":                "ID",
+	"csv.created_at":        "Oluşturulma tarihi",
+	"csv.entrance":          "Giriş",
+	"csv.floor":             "Kat",
+	"csv.apartment":         "Daire",
+	"csv.windows_same":      "Aynı kanatlar",
+	"csv.balcony_count":     "Balkonlar",
+	"csv.balcony_type":      "Balkon tipi",
+	"csv.balcony_sash":      "Balkon kanatları",
+	"csv.loggias":           "Balkon detayları",
+	"csv.addons":            "Ek hizmetler",
+	"csv.description":       "Açıklama",
+	"csv.comment":           "Yorum",
+	"csv.intercom_code":     "Diafon kodu",
+	"csv.presence_required": "Evde bulunma",
+	"csv.preferred_time":    "Uygun zaman",
+	"csv.telegram_nick":     "Telegram kullanıcı adı",
+	"csv.price":             "Fiyat",
+	"csv.status":            "Durum",
+	"csv.is_current":        "Güncel",
+	"csv.user_id":           "Kullanıcı ID",
+	"csv.username":          "Username",
+	"csv.first_name":        "Ad",
+	"csv.last_name":         "Soyad",
+	"csv.source":            "Kaynak",
+	"csv.customer_name":     "Müşteri adı",
+	"csv.customer_phone":    "Müşteri telefonu",
+}
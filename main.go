@@ -1,12 +1,16 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/eugenepelipets/window-wash-bot/bot"
+	"github.com/eugenepelipets/window-wash-bot/clock"
 	"github.com/eugenepelipets/window-wash-bot/storage"
 	"github.com/joho/godotenv"
 )
@@ -22,6 +26,12 @@ func main() {
 		log.Printf("⚠️ Не удалось загрузить .env файл: %v", err)
 	}
 
+	// Часовой пояс развертывания
+	if err := clock.Init(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	log.Printf("🕒 Часовой пояс: %s", clock.Location())
+
 	// Подключение к БД
 	db, err := storage.NewPostgres()
 	if err != nil {
@@ -30,6 +40,19 @@ func main() {
 	defer db.Pool.Close()
 	log.Println("✅ Подключение к БД установлено")
 
+	// Каталог изделий нужен и боту, и служебным командам
+	if err := bot.LoadItemCatalog(db); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// Служебные команды: window-wash-bot import <файл>, window-wash-bot export [параметры]
+	if len(os.Args) > 1 {
+		if err := runCommand(db, os.Args[1:]); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
 	// Создание бота
 	telegramBot, err := bot.NewBot(db)
 	if err != nil {
@@ -54,3 +77,78 @@ func main() {
 	// Здесь можно добавить graceful shutdown логику
 	log.Println("✅ Бот успешно остановлен")
 }
+
+// runCommand выполняет служебную команду без запуска бота
+func runCommand(db *storage.Postgres, args []string) error {
+	switch args[0] {
+	case "import":
+		if len(args) != 2 {
+			return fmt.Errorf("использование: %s import <файл.csv|файл.xlsx>", os.Args[0])
+		}
+		return importOrders(db, args[1])
+	case "export":
+		return exportOrders(db, args[1:])
+	default:
+		return fmt.Errorf("неизвестная команда %q", args[0])
+	}
+}
+
+// importOrders загружает заказы из файла; строки с ошибками записываются рядом с файлом
+func importOrders(db *storage.Postgres, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	result, err := bot.ImportOrders(db, path, data, 0)
+	if err != nil {
+		return fmt.Errorf("ошибка импорта: %v", err)
+	}
+	log.Printf("✅ Импорт завершен: строк %d, загружено %d, с ошибками %d",
+		result.Total, result.Imported, len(result.Errors))
+	if len(result.Errors) == 0 {
+		return nil
+	}
+
+	report, err := bot.ImportErrorsCSV(result)
+	if err != nil {
+		return err
+	}
+	reportPath := strings.TrimSuffix(path, filepath.Ext(path)) + "_errors.csv"
+	if err := os.WriteFile(reportPath, report, 0o644); err != nil {
+		return err
+	}
+	log.Printf("⚠️ Строки с ошибками записаны в %s", reportPath)
+	return nil
+}
+
+// exportOrders сохраняет выгрузку в файл. Параметры те же, что у /export, например:
+// export json from=2026-04-01 to=2026-04-30 --out orders.json
+func exportOrders(db *storage.Postgres, args []string) error {
+	var outPath string
+	var filters []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--out" || args[i] == "-o" {
+			if i+1 >= len(args) {
+				return fmt.Errorf("после %s нужно указать имя файла", args[i])
+			}
+			outPath = args[i+1]
+			i++
+			continue
+		}
+		filters = append(filters, args[i])
+	}
+
+	fileName, data, err := bot.ExportOrders(db, strings.Join(filters, " "))
+	if err != nil {
+		return fmt.Errorf("ошибка выгрузки: %v", err)
+	}
+	if outPath == "" {
+		outPath = fileName
+	}
+	if err := os.WriteFile(outPath, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("✅ Выгрузка сохранена в %s", outPath)
+	return nil
+}
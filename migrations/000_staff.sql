@@ -0,0 +1,19 @@
+-- Сотрудники и их роли. Нужен только для базы, созданной до появления ролей; новая база
+-- создается из schema.sql. Миграции выполняются по порядку номеров.
+--
+-- psql -d windowwash -f migrations/000_staff.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS staff
+(
+    id          SERIAL PRIMARY KEY,
+    telegram_id BIGINT      NOT NULL UNIQUE,
+    role        VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'dispatcher', 'washer', 'accountant')),
+    added_by    BIGINT,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_staff_role ON staff (role);
+
+COMMIT;
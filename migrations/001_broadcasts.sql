@@ -0,0 +1,38 @@
+-- Рассылки и их получатели со статусом доставки. claimed_at — когда рассылку поставил
+-- в очередь запущенный бот: после перезапуска продолжаются только рассылки, поставленные
+-- до запуска. Нужен только для базы, созданной до появления /broadcast; новая база
+-- создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/001_broadcasts.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS broadcasts
+(
+    id          SERIAL PRIMARY KEY,
+    created_by  BIGINT      NOT NULL,
+    text        TEXT        NOT NULL,
+    audience    VARCHAR(50) NOT NULL,
+    total       INTEGER     NOT NULL DEFAULT 0,
+    delivered   INTEGER     NOT NULL DEFAULT 0,
+    blocked     INTEGER     NOT NULL DEFAULT 0,
+    failed      INTEGER     NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    claimed_at  TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS broadcast_recipients
+(
+    broadcast_id INTEGER     NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    telegram_id  BIGINT      NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error        TEXT,
+    sent_at      TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (broadcast_id, telegram_id)
+);
+
+ALTER TABLE broadcasts
+    ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
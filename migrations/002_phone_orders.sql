@@ -0,0 +1,22 @@
+-- Заказы, принятые по телефону: у них нет пользователя бота, поэтому user_id становится
+-- необязательным и теряет внешний ключ на users, а клиент записывается в customer_name и
+-- customer_phone. Источник заказа по умолчанию — бот. Нужен только для базы, созданной до
+-- появления /neworder; новая база создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/002_phone_orders.sql
+
+BEGIN;
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_user_id_fkey;
+
+ALTER TABLE orders
+    ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS source         VARCHAR(20) NOT NULL DEFAULT 'bot' CHECK (source IN ('bot', 'phone', 'import')),
+    ADD COLUMN IF NOT EXISTS customer_name  VARCHAR(200),
+    ADD COLUMN IF NOT EXISTS customer_phone VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS created_by     BIGINT;
+
+COMMIT;
@@ -0,0 +1,30 @@
+-- Задачи планировщика и журнал их запусков. Нужен только для базы, созданной до появления
+-- планировщика; новая база создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/003_scheduled_jobs.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scheduled_jobs
+(
+    name               VARCHAR(50) PRIMARY KEY,
+    cron               VARCHAR(100) NOT NULL,
+    enabled            BOOLEAN      NOT NULL DEFAULT TRUE,
+    params             TEXT         NOT NULL DEFAULT '',
+    last_scheduled_for TIMESTAMP WITH TIME ZONE,
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS job_runs
+(
+    id            SERIAL PRIMARY KEY,
+    job_name      VARCHAR(50) NOT NULL REFERENCES scheduled_jobs (name) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    started_at    TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at   TIMESTAMP WITH TIME ZONE,
+    status        VARCHAR(20) NOT NULL DEFAULT 'running',
+    error         TEXT,
+    UNIQUE (job_name, scheduled_for)
+);
+
+COMMIT;
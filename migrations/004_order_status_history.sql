@@ -0,0 +1,25 @@
+-- История статусов заказа. Для уже существующих заказов история начинается с их текущего
+-- статуса на момент создания заказа. Нужен только для базы, созданной до появления истории;
+-- новая база создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/004_order_status_history.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_status_history
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    status     VARCHAR(20) NOT NULL,
+    changed_by BIGINT,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history (order_id);
+
+INSERT INTO order_status_history (order_id, status, changed_at)
+SELECT o.id, o.status, o.created_at
+FROM orders o
+WHERE NOT EXISTS (SELECT 1 FROM order_status_history h WHERE h.order_id = o.id);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Телефон пользователя, полученный кнопкой «Отправить контакт». Нужен только для базы,
+-- созданной до его появления; новая база создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/005_user_phone.sql
+
+BEGIN;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(20);
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Язык интерфейса пользователя: язык клиента Telegram при первом сохранении или выбранный
+-- через /language. Нужен только для базы, созданной до его появления; новая база создается
+-- из schema.sql.
+--
+-- psql -d windowwash -f migrations/006_user_language.sql
+
+BEGIN;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS language VARCHAR(5);
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Перенос лоджий из колонок orders (balcony_count, balcony_type, balcony_sash) в order_loggias:
+-- каждая лоджия заказа становится отдельной строкой с типом и створками, общими для всех
+-- лоджий заказа. Остекление раньше не указывалось, поэтому остается пустым.
+-- Нужен только для базы, созданной до появления order_loggias; новая база создается
+-- из schema.sql. Выполняется перед остальными миграциями данных заказа.
+--
+-- psql -d windowwash -f migrations/007_order_loggias.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_loggias
+(
+    id          SERIAL PRIMARY KEY,
+    order_id    INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    loggia_type VARCHAR(20) NOT NULL CHECK (loggia_type IN ('standard', 'floor')),
+    sash        VARCHAR(10) NOT NULL CHECK (sash IN ('3', '4', '5', '6_7')),
+    glazing     VARCHAR(20) CHECK (glazing IN ('cold', 'warm'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_loggias_order ON order_loggias (order_id);
+
+-- Тип лоджии бот всегда сохранял вместе со створками; пустой тип считаем стандартным
+INSERT INTO order_loggias (order_id, loggia_type, sash)
+SELECT o.id, COALESCE(NULLIF(o.balcony_type, ''), 'standard'), o.balcony_sash
+FROM orders o
+         CROSS JOIN generate_series(1, o.balcony_count)
+WHERE o.balcony_count > 0
+  AND o.balcony_sash IN ('3', '4', '5', '6_7');
+
+ALTER TABLE orders
+    DROP COLUMN balcony_count,
+    DROP COLUMN balcony_type,
+    DROP COLUMN balcony_sash;
+
+COMMIT;
@@ -0,0 +1,54 @@
+-- Перенос количества окон из колонок orders (window_3_count … window_6_7_count) в order_items.
+-- Нужен только для базы, созданной до появления каталога изделий; новая база создается
+-- из schema.sql. Цена за штуку у перенесенных позиций — по текущему каталогу.
+--
+-- psql -d windowwash -f migrations/008_order_items.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS item_types
+(
+    code     VARCHAR(30) PRIMARY KEY,
+    titles   JSONB       NOT NULL,
+    sash     VARCHAR(10),
+    price    INTEGER     NOT NULL CHECK (price >= 0),
+    position INTEGER     NOT NULL DEFAULT 0,
+    active   BOOLEAN     NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO item_types (code, titles, sash, price, position)
+VALUES ('window_3', '{"ru": "3-створчатые", "en": "3-sash", "tr": "3 kanatlı"}', '3', 1000, 10),
+       ('window_4', '{"ru": "4-створчатые", "en": "4-sash", "tr": "4 kanatlı"}', '4', 1500, 20),
+       ('window_5', '{"ru": "5-створчатые", "en": "5-sash", "tr": "5 kanatlı"}', '5', 2000, 30),
+       ('window_6_7', '{"ru": "6-7-створчатые", "en": "6-7-sash", "tr": "6-7 kanatlı"}', '6_7', 2500, 40)
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS order_items
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    item_type  VARCHAR(30) NOT NULL REFERENCES item_types (code),
+    quantity   INTEGER     NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER     NOT NULL,
+    UNIQUE (order_id, item_type)
+);
+
+INSERT INTO order_items (order_id, item_type, quantity, unit_price)
+SELECT o.id, t.code, c.quantity, t.price
+FROM orders o
+         CROSS JOIN LATERAL (VALUES ('window_3', o.window_3_count),
+                                    ('window_4', o.window_4_count),
+                                    ('window_5', o.window_5_count),
+                                    ('window_6_7', o.window_6_7_count)) AS c (item_type, quantity)
+         JOIN item_types t ON t.code = c.item_type
+WHERE c.quantity > 0
+ON CONFLICT (order_id, item_type) DO NOTHING;
+
+ALTER TABLE orders
+    DROP COLUMN window_type,
+    DROP COLUMN window_3_count,
+    DROP COLUMN window_4_count,
+    DROP COLUMN window_5_count,
+    DROP COLUMN window_6_7_count;
+
+COMMIT;
@@ -0,0 +1,37 @@
+-- Каталог дополнительных услуг и услуги заказов.
+-- Нужен только для базы, созданной до появления дополнительных услуг; новая база создается
+-- из schema.sql.
+--
+-- psql -d windowwash -f migrations/009_order_addons.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS addon_types
+(
+    code       VARCHAR(30) PRIMARY KEY,
+    titles     JSONB       NOT NULL,
+    unit       VARCHAR(10) NOT NULL DEFAULT 'piece' CHECK (unit IN ('piece', 'order')),
+    applies_to VARCHAR(10) NOT NULL DEFAULT 'windows' CHECK (applies_to IN ('windows', 'loggias', 'all')),
+    price      INTEGER     NOT NULL CHECK (price >= 0),
+    position   INTEGER     NOT NULL DEFAULT 0,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO addon_types (code, titles, unit, applies_to, price, position)
+VALUES ('frames', '{"ru": "Мытье рам", "en": "Frame cleaning", "tr": "Çerçeve temizliği"}', 'piece', 'all', 300, 10),
+       ('sills', '{"ru": "Мытье подоконников", "en": "Window sill cleaning", "tr": "Denizlik temizliği"}', 'piece', 'windows', 200, 20),
+       ('nets', '{"ru": "Мытье москитных сеток", "en": "Mosquito net cleaning", "tr": "Sineklik temizliği"}', 'piece', 'windows', 150, 30),
+       ('blinds', '{"ru": "Чистка жалюзи", "en": "Blind cleaning", "tr": "Jaluzi temizliği"}', 'piece', 'windows', 400, 40)
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS order_addons
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    addon_type VARCHAR(30) NOT NULL REFERENCES addon_types (code),
+    quantity   INTEGER     NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER     NOT NULL,
+    UNIQUE (order_id, addon_type)
+);
+
+COMMIT;
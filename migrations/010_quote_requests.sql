@@ -0,0 +1,20 @@
+-- Заявки на расчет нестандартных окон: описание в orders и фото в order_photos.
+-- Нужен только для базы, созданной до появления заявок; новая база создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/010_quote_requests.sql
+
+BEGIN;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS description TEXT;
+
+CREATE TABLE IF NOT EXISTS order_photos
+(
+    id       SERIAL PRIMARY KEY,
+    order_id INTEGER      NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    file_id  VARCHAR(200) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_photos_order ON order_photos (order_id);
+
+COMMIT;
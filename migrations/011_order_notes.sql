@@ -0,0 +1,16 @@
+-- Комментарий к заказу и условия доступа в квартиру: код домофона, нужно ли присутствие
+-- клиента и удобное время. Нужен только для базы, созданной до их появления; новая база
+-- создается из schema.sql.
+--
+-- psql -d windowwash -f migrations/011_order_notes.sql
+
+BEGIN;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS comment           TEXT,
+    ADD COLUMN IF NOT EXISTS intercom_code     VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS presence_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS preferred_time    VARCHAR(20)
+        CHECK (preferred_time IN ('morning', 'afternoon', 'evening'));
+
+COMMIT;
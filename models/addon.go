@@ -0,0 +1,34 @@
+package models
+
+// Единицы, за которые берется цена дополнительной услуги
+const (
+	AddonUnitPiece = "piece" // за каждое окно или лоджию, к которым применима услуга
+	AddonUnitOrder = "order" // один раз за заказ
+)
+
+// К чему применима дополнительная услуга
+const (
+	AddonForWindows = "windows"
+	AddonForLoggias = "loggias"
+	AddonForAll     = "all"
+)
+
+// AddonType — дополнительная услуга из каталога: мытье рам, подоконников, москитных сеток и т. п.
+type AddonType struct {
+	Code      string            `db:"code"`       // "frames", "sills", ...
+	Titles    map[string]string `db:"titles"`     // название по языкам интерфейса: ru, en, tr
+	Unit      string            `db:"unit"`       // AddonUnitPiece или AddonUnitOrder
+	AppliesTo string            `db:"applies_to"` // AddonForWindows, AddonForLoggias или AddonForAll
+	Price     int               `db:"price"`      // цена за единицу, руб.
+	Position  int               `db:"position"`   // порядок в диалоге и выгрузках
+	Active    bool              `db:"active"`     // предлагается ли клиентам
+}
+
+// OrderAddon — дополнительная услуга в заказе
+type OrderAddon struct {
+	ID        int64  `db:"id"`
+	OrderID   int64  `db:"order_id"`
+	AddonType string `db:"addon_type"`
+	Quantity  int    `db:"quantity"`   // количество единиц на момент расчета
+	UnitPrice int    `db:"unit_price"` // цена за единицу на момент заказа
+}
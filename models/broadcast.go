@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Аудитории рассылки
+const (
+	AudienceAll       = "all"       // все пользователи бота
+	AudienceCustomers = "customers" // все клиенты дома (хотя бы один заказ)
+	AudienceEntrance  = "entrance"  // клиенты с актуальным заказом в подъезде
+	AudienceStatus    = "status"    // клиенты с актуальным заказом в статусе
+	AudienceNoOrders  = "no_orders" // клиенты прошлых сезонов без заказа в текущем
+)
+
+// Статусы доставки сообщения получателю
+const (
+	RecipientPending   = "pending"
+	RecipientDelivered = "delivered"
+	RecipientBlocked   = "blocked"
+	RecipientFailed    = "failed"
+)
+
+// BroadcastAudience — выбранная администратором аудитория рассылки
+type BroadcastAudience struct {
+	Kind        string
+	Entrance    int       // для AudienceEntrance
+	Status      string    // для AudienceStatus
+	SeasonStart time.Time // для AudienceNoOrders
+}
+
+type Broadcast struct {
+	ID         int64     `db:"id"`
+	CreatedBy  int64     `db:"created_by"`
+	Text       string    `db:"text"`
+	Audience   string    `db:"audience"`
+	Total      int       `db:"total"`
+	Delivered  int       `db:"delivered"`
+	Blocked    int       `db:"blocked"`
+	Failed     int       `db:"failed"`
+	CreatedAt  time.Time `db:"created_at"`
+	ClaimedAt  time.Time `db:"claimed_at"` // когда рассылку поставили в очередь отправки
+	FinishedAt time.Time `db:"finished_at"`
+}
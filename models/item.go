@@ -0,0 +1,20 @@
+package models
+
+// ItemType — позиция каталога изделий: тип окна или другое изделие, которое моют поштучно
+type ItemType struct {
+	Code     string            `db:"code"`     // "window_3", "window_6_7", ...
+	Titles   map[string]string `db:"titles"`   // название по языкам интерфейса: ru, en, tr
+	Sash     string            `db:"sash"`     // количество створок у окон ("3", "6_7"); пусто для других изделий
+	Price    int               `db:"price"`    // цена за штуку, руб.
+	Position int               `db:"position"` // порядок в диалоге и выгрузках
+	Active   bool              `db:"active"`   // предлагается ли клиентам
+}
+
+// OrderItem — позиция заказа
+type OrderItem struct {
+	ID        int64  `db:"id"`
+	OrderID   int64  `db:"order_id"`
+	ItemType  string `db:"item_type"`
+	Quantity  int    `db:"quantity"`
+	UnitPrice int    `db:"unit_price"` // цена за штуку на момент заказа
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Статусы запуска задачи
+const (
+	JobRunRunning = "running"
+	JobRunSuccess = "success"
+	JobRunFailed  = "failed"
+)
+
+// ScheduledJob — задача планировщика
+type ScheduledJob struct {
+	Name             string    `db:"name"`
+	Cron             string    `db:"cron"`
+	Enabled          bool      `db:"enabled"`
+	Params           string    `db:"params"`             // аргументы задачи, например "format=xlsx"
+	LastScheduledFor time.Time `db:"last_scheduled_for"` // время последнего обработанного запуска по расписанию
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// JobRun — запись истории запусков
+type JobRun struct {
+	ID           int64     `db:"id"`
+	JobName      string    `db:"job_name"`
+	ScheduledFor time.Time `db:"scheduled_for"`
+	StartedAt    time.Time `db:"started_at"`
+	FinishedAt   time.Time `db:"finished_at"`
+	Status       string    `db:"status"` // "running", "success", "failed"
+	Error        string    `db:"error"`
+}
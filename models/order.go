@@ -2,25 +2,161 @@ package models
 
 import "time"
 
+// StatusAwaitingQuote — заявка на нестандартные окна ждет, пока администратор назначит цену
+// и клиент ее примет
+const StatusAwaitingQuote = "awaiting_quote"
+
+// Удобное клиенту время мойки; пустое значение — в любое время
+const (
+	PreferredTimeMorning   = "morning"
+	PreferredTimeAfternoon = "afternoon"
+	PreferredTimeEvening   = "evening"
+)
+
+// Источники заказа
+const (
+	OrderSourceBot    = "bot"    // оформлен клиентом в боте
+	OrderSourcePhone  = "phone"  // принят администратором по телефону
+	OrderSourceImport = "import" // загружен из файла
+)
+
 type Order struct {
-	ID             int64     `db:"id"`
-	UserID         int64     `db:"user_id"`
-	Entrance       int       `db:"entrance"`
-	Floor          int       `db:"floor"`
-	Apartment      string    `db:"apartment"`
-	WindowType     string    `db:"window_type"` // "3_same", "4_same", "5_same", "6_7_same", "different"
-	WindowsSame    bool      `db:"windows_same"`
-	Window3Count   int       `db:"window_3_count"`
-	Window4Count   int       `db:"window_4_count"`
-	Window5Count   int       `db:"window_5_count"`
-	Window6_7Count int       `db:"window_6_7_count"`
-	BalconyCount   int       `db:"balcony_count"`
-	BalconyType    string    `db:"balcony_type"`
-	BalconySash    string    `db:"balcony_sash"`
-	TelegramNick   string    `db:"telegram_nick"`
-	Price          int       `db:"price"`
-	Status         string    `db:"status"` // "confirmed", "needs_clarification", "canceled"
-	IsCurrent      bool      `db:"is_current"`
-	CreatedAt      time.Time `db:"created_at"`
-	User           User      `db:"-"`
+	ID            int64        `db:"id"`
+	UserID        int64        `db:"user_id"` // 0 для заказов без Telegram
+	Entrance      int          `db:"entrance"`
+	Floor         int          `db:"floor"`
+	Apartment     string       `db:"apartment"`
+	WindowsSame   bool         `db:"windows_same"` // клиент выбрал один тип окон для всех
+	Items         []OrderItem  `db:"-"`            // хранятся в таблице order_items
+	Loggias       []Loggia     `db:"-"`            // хранятся в таблице order_loggias
+	Addons        []OrderAddon `db:"-"`            // хранятся в таблице order_addons
+	TelegramNick  string       `db:"telegram_nick"`
+	Price         int          `db:"price"`
+	Status        string       `db:"status"` // "confirmed", "needs_clarification", "canceled", "awaiting_quote"
+	IsCurrent     bool         `db:"is_current"`
+	Source        string       `db:"source"`         // "bot", "phone", "import"
+	CustomerName  string       `db:"customer_name"`  // для заказов без Telegram
+	CustomerPhone string       `db:"customer_phone"` // телефон для связи в формате E.164
+	CreatedBy     int64        `db:"created_by"`     // администратор, оформивший заказ (0 — сам клиент)
+	Description   string       `db:"description"`    // описание нестандартных окон в заявке на расчет
+	Photos        []string     `db:"-"`              // file_id фото из заявки, хранятся в таблице order_photos
+	Comment       string       `db:"comment"`        // комментарий клиента: «собака дома», «позвонить за час»
+	IntercomCode  string       `db:"intercom_code"`
+	Presence      bool         `db:"presence_required"` // клиент должен быть дома во время мойки
+	PreferredTime string       `db:"preferred_time"`    // morning, afternoon, evening или пусто
+	CreatedAt     time.Time    `db:"created_at"`
+	User          User         `db:"-"`
+}
+
+// CountsInRevenue сообщает, входит ли заказ в выручку и сводки: учитываются только
+// подтвержденные актуальные заказы. Отмененные, неподтвержденные и замененные повторным
+// заказом квартиры не учитываются.
+func (o Order) CountsInRevenue() bool {
+	return o.Status == "confirmed" && o.IsCurrent
+}
+
+// Quantity возвращает количество изделий типа itemType в заказе
+func (o Order) Quantity(itemType string) int {
+	for _, item := range o.Items {
+		if item.ItemType == itemType {
+			return item.Quantity
+		}
+	}
+	return 0
+}
+
+// SetQuantity задает количество изделий типа itemType; при нулевом количестве позиция убирается
+func (o *Order) SetQuantity(itemType string, quantity int) {
+	for i, item := range o.Items {
+		if item.ItemType != itemType {
+			continue
+		}
+		if quantity > 0 {
+			o.Items[i].Quantity = quantity
+		} else {
+			o.Items = append(o.Items[:i], o.Items[i+1:]...)
+		}
+		return
+	}
+	if quantity > 0 {
+		o.Items = append(o.Items, OrderItem{ItemType: itemType, Quantity: quantity})
+	}
+}
+
+// ItemCount возвращает общее количество изделий в заказе без учета лоджий
+func (o Order) ItemCount() int {
+	total := 0
+	for _, item := range o.Items {
+		total += item.Quantity
+	}
+	return total
+}
+
+// HasAddon проверяет, выбрана ли в заказе дополнительная услуга
+func (o Order) HasAddon(addonType string) bool {
+	for _, addon := range o.Addons {
+		if addon.AddonType == addonType {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleAddon добавляет дополнительную услугу в заказ или убирает ее, если она уже выбрана
+func (o *Order) ToggleAddon(addonType string) {
+	for i, addon := range o.Addons {
+		if addon.AddonType == addonType {
+			o.Addons = append(o.Addons[:i], o.Addons[i+1:]...)
+			return
+		}
+	}
+	o.Addons = append(o.Addons, OrderAddon{AddonType: addonType})
+}
+
+// Типы окон на лоджии
+const (
+	LoggiaStandard = "standard" // стандартные
+	LoggiaFloor    = "floor"    // до пола
+)
+
+// Виды остекления лоджии
+const (
+	GlazingCold = "cold" // холодное, алюминиевый профиль
+	GlazingWarm = "warm" // теплое, пластиковые стеклопакеты
+)
+
+// Loggia — лоджия в заказе; у каждой лоджии свои окна и своя цена
+type Loggia struct {
+	ID      int64  `db:"id"`
+	OrderID int64  `db:"order_id"`
+	Type    string `db:"loggia_type"` // "standard", "floor"
+	Sash    string `db:"sash"`        // "3", "4", "5", "6_7"
+	Glazing string `db:"glazing"`     // "cold", "warm" или пусто, если клиент не знает
+}
+
+// OrderStatusChange — запись истории статусов заказа
+type OrderStatusChange struct {
+	OrderID   int64
+	Status    string
+	ChangedBy int64 // 0 — изменен клиентом или системой
+	ChangedAt time.Time
+}
+
+// OrderSearch — критерии поиска заказов администратором (пустые поля не учитываются)
+type OrderSearch struct {
+	OrderID    int64
+	TelegramID int64
+	UserName   string
+	Entrance   int
+	Floor      int
+	Apartment  string
+}
+
+// ExportFilter — фильтры выгрузки заказов (пустые поля не учитываются)
+type ExportFilter struct {
+	OnlyCurrent bool
+	From        time.Time // включительно
+	To          time.Time // не включительно
+	Entrance    int
+	Status      string
 }
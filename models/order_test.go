@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestCountsInRevenue(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  bool
+	}{
+		{name: "подтвержденный актуальный", order: Order{Status: "confirmed", IsCurrent: true}, want: true},
+		{name: "замененный повторным заказом", order: Order{Status: "confirmed", IsCurrent: false}},
+		{name: "на уточнении", order: Order{Status: "needs_clarification", IsCurrent: true}},
+		{name: "ожидает", order: Order{Status: "pending", IsCurrent: true}},
+		{name: "заявка на расчет", order: Order{Status: StatusAwaitingQuote}},
+		{name: "отмененный", order: Order{Status: "canceled", IsCurrent: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.CountsInRevenue(); got != tt.want {
+				t.Errorf("CountsInRevenue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
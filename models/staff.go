@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Роли сотрудников
+const (
+	RoleOwner      = "owner"
+	RoleDispatcher = "dispatcher"
+	RoleWasher     = "washer"
+	RoleAccountant = "accountant"
+)
+
+type Staff struct {
+	ID         int64     `db:"id"`
+	TelegramID int64     `db:"telegram_id"`
+	Role       string    `db:"role"` // "owner", "dispatcher", "washer", "accountant"
+	AddedBy    int64     `db:"added_by"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// IsValidRole проверяет, что роль входит в список известных
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleDispatcher, RoleWasher, RoleAccountant:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// OrderStats — агрегированная статистика по заказам за период
+type OrderStats struct {
+	From              time.Time
+	To                time.Time
+	TotalOrders       int
+	Revenue           int            // сумма по подтвержденным актуальным заказам
+	AverageCheck      int            // средний чек по тем же заказам
+	ByStatus          map[string]int // количество заказов по статусам
+	ItemCounts        map[string]int // количество изделий по кодам каталога
+	OrdersWithLoggia  int
+	LoggiaCount       int
+	ByEntrance        map[int]int // количество заказов по подъездам
+	RevenueByEntrance map[int]int
+	TopFloors         []FloorCount
+	NewUsers          int // пользователи, впервые нажавшие /start в периоде
+	Customers         int // пользователи, оформившие заказ в периоде
+}
+
+type FloorCount struct {
+	Floor  int
+	Orders int
+}
+
+// DailyCount — количество заказов и выручка за один день
+type DailyCount struct {
+	Day     time.Time
+	Orders  int
+	Revenue int
+}
@@ -8,5 +8,7 @@ type User struct {
 	UserName   string    `db:"username"`
 	FirstName  string    `db:"first_name"`
 	LastName   string    `db:"last_name"`
+	Phone      string    `db:"phone"`    // в формате E.164, сохраняется при первом заказе
+	Language   string    `db:"language"` // код языка интерфейса: ru, en, tr
 	CreatedAt  time.Time `db:"created_at"`
 }
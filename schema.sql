@@ -1,5 +1,17 @@
 DROP TABLE IF EXISTS users,
-    orders;
+    orders,
+    item_types,
+    order_items,
+    addon_types,
+    order_addons,
+    order_photos,
+    order_loggias,
+    staff,
+    broadcasts,
+    broadcast_recipients,
+    scheduled_jobs,
+    job_runs,
+    order_status_history;
 
 CREATE TABLE IF NOT EXISTS users
 (
@@ -8,14 +20,15 @@ CREATE TABLE IF NOT EXISTS users
     username    VARCHAR(100) NOT NULL,
     first_name  VARCHAR(100) NOT NULL,
     last_name   VARCHAR(100) NOT NULL,
+    phone       VARCHAR(20),
+    language    VARCHAR(5),
     created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
 CREATE TABLE IF NOT EXISTS orders
 (
     id               SERIAL PRIMARY KEY,
-    user_id          BIGINT      NOT NULL,
-    window_type      VARCHAR(20)              DEFAULT 'different' NOT NULL,
+    user_id          BIGINT,
     floor            INTEGER     NOT NULL,
     apartment        VARCHAR(10) NOT NULL,
     price            INTEGER     NOT NULL,
@@ -24,17 +37,171 @@ CREATE TABLE IF NOT EXISTS orders
     created_at       TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     entrance         INTEGER     NOT NULL     DEFAULT 1,
     windows_same     BOOLEAN     NOT NULL     DEFAULT TRUE,
-    window_3_count   INTEGER                  DEFAULT 0,
-    window_4_count   INTEGER                  DEFAULT 0,
-    window_5_count   INTEGER                  DEFAULT 0,
-    window_6_7_count INTEGER                  DEFAULT 0,
-    balcony_count    INTEGER                  DEFAULT 0,
-    balcony_type     VARCHAR(20),
-    balcony_sash     VARCHAR(10),
     telegram_nick    VARCHAR(100),
-    FOREIGN KEY (user_id) REFERENCES users (telegram_id)
+    source           VARCHAR(20) NOT NULL     DEFAULT 'bot' CHECK (source IN ('bot', 'phone', 'import')),
+    customer_name    VARCHAR(200),
+    customer_phone   VARCHAR(20),
+    created_by       BIGINT,
+    description      TEXT, -- описание нестандартных окон в заявке на расчет
+    comment          TEXT, -- комментарий клиента к заказу
+    intercom_code    VARCHAR(20),
+    presence_required BOOLEAN    NOT NULL     DEFAULT FALSE, -- клиент должен быть дома во время мойки
+    preferred_time   VARCHAR(20) CHECK (preferred_time IN ('morning', 'afternoon', 'evening')) -- пусто — в любое время
+);
+
+-- Каталог изделий: типы окон и другие позиции, которые моют поштучно.
+-- Новая позиция (2-створчатое окно, балконная дверь, мансардное окно) добавляется строкой
+-- в этой таблице и появляется в диалоге, расчете и выгрузках после перезапуска бота.
+CREATE TABLE IF NOT EXISTS item_types
+(
+    code     VARCHAR(30) PRIMARY KEY,
+    titles   JSONB       NOT NULL, -- названия по языкам: {"ru": ..., "en": ..., "tr": ...}
+    sash     VARCHAR(10),          -- количество створок у окон; по нему считаются лоджии
+    price    INTEGER     NOT NULL CHECK (price >= 0),
+    position INTEGER     NOT NULL DEFAULT 0,
+    active   BOOLEAN     NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO item_types (code, titles, sash, price, position)
+VALUES ('window_3', '{"ru": "3-створчатые", "en": "3-sash", "tr": "3 kanatlı"}', '3', 1000, 10),
+       ('window_4', '{"ru": "4-створчатые", "en": "4-sash", "tr": "4 kanatlı"}', '4', 1500, 20),
+       ('window_5', '{"ru": "5-створчатые", "en": "5-sash", "tr": "5 kanatlı"}', '5', 2000, 30),
+       ('window_6_7', '{"ru": "6-7-створчатые", "en": "6-7-sash", "tr": "6-7 kanatlı"}', '6_7', 2500, 40)
+ON CONFLICT (code) DO NOTHING;
+
+-- Позиции заказа: количество изделий каждого типа и цена за штуку на момент заказа
+CREATE TABLE IF NOT EXISTS order_items
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    item_type  VARCHAR(30) NOT NULL REFERENCES item_types (code),
+    quantity   INTEGER     NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER     NOT NULL,
+    UNIQUE (order_id, item_type)
+);
+
+-- Каталог дополнительных услуг. Цена берется за каждое окно и/или лоджию (unit = 'piece')
+-- или один раз за заказ (unit = 'order'); клиенту предлагаются только услуги, применимые к его заказу.
+CREATE TABLE IF NOT EXISTS addon_types
+(
+    code       VARCHAR(30) PRIMARY KEY,
+    titles     JSONB       NOT NULL, -- названия по языкам: {"ru": ..., "en": ..., "tr": ...}
+    unit       VARCHAR(10) NOT NULL DEFAULT 'piece' CHECK (unit IN ('piece', 'order')),
+    applies_to VARCHAR(10) NOT NULL DEFAULT 'windows' CHECK (applies_to IN ('windows', 'loggias', 'all')),
+    price      INTEGER     NOT NULL CHECK (price >= 0),
+    position   INTEGER     NOT NULL DEFAULT 0,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO addon_types (code, titles, unit, applies_to, price, position)
+VALUES ('frames', '{"ru": "Мытье рам", "en": "Frame cleaning", "tr": "Çerçeve temizliği"}', 'piece', 'all', 300, 10),
+       ('sills', '{"ru": "Мытье подоконников", "en": "Window sill cleaning", "tr": "Denizlik temizliği"}', 'piece', 'windows', 200, 20),
+       ('nets', '{"ru": "Мытье москитных сеток", "en": "Mosquito net cleaning", "tr": "Sineklik temizliği"}', 'piece', 'windows', 150, 30),
+       ('blinds', '{"ru": "Чистка жалюзи", "en": "Blind cleaning", "tr": "Jaluzi temizliği"}', 'piece', 'windows', 400, 40)
+ON CONFLICT (code) DO NOTHING;
+
+-- Дополнительные услуги заказа: количество единиц и цена за единицу на момент заказа
+CREATE TABLE IF NOT EXISTS order_addons
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    addon_type VARCHAR(30) NOT NULL REFERENCES addon_types (code),
+    quantity   INTEGER     NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER     NOT NULL,
+    UNIQUE (order_id, addon_type)
+);
+
+-- Фото из заявки на расчет нестандартных окон (file_id Telegram)
+CREATE TABLE IF NOT EXISTS order_photos
+(
+    id       SERIAL PRIMARY KEY,
+    order_id INTEGER      NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    file_id  VARCHAR(200) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_photos_order ON order_photos (order_id);
+
+-- Лоджии заказа: у каждой свой тип окон, количество створок и остекление
+CREATE TABLE IF NOT EXISTS order_loggias
+(
+    id          SERIAL PRIMARY KEY,
+    order_id    INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    loggia_type VARCHAR(20) NOT NULL CHECK (loggia_type IN ('standard', 'floor')),
+    sash        VARCHAR(10) NOT NULL CHECK (sash IN ('3', '4', '5', '6_7')),
+    glazing     VARCHAR(20) CHECK (glazing IN ('cold', 'warm'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_loggias_order ON order_loggias (order_id);
+
+-- История статусов заказа: первая запись добавляется при создании заказа
+CREATE TABLE IF NOT EXISTS order_status_history
+(
+    id         SERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    status     VARCHAR(20) NOT NULL,
+    changed_by BIGINT,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order ON order_status_history (order_id);
+
+CREATE TABLE IF NOT EXISTS staff
+(
+    id          SERIAL PRIMARY KEY,
+    telegram_id BIGINT      NOT NULL UNIQUE,
+    role        VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'dispatcher', 'washer', 'accountant')),
+    added_by    BIGINT,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS broadcasts
+(
+    id          SERIAL PRIMARY KEY,
+    created_by  BIGINT      NOT NULL,
+    text        TEXT        NOT NULL,
+    audience    VARCHAR(50) NOT NULL,
+    total       INTEGER     NOT NULL DEFAULT 0,
+    delivered   INTEGER     NOT NULL DEFAULT 0,
+    blocked     INTEGER     NOT NULL DEFAULT 0,
+    failed      INTEGER     NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    claimed_at  TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS broadcast_recipients
+(
+    broadcast_id INTEGER     NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    telegram_id  BIGINT      NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error        TEXT,
+    sent_at      TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (broadcast_id, telegram_id)
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_jobs
+(
+    name               VARCHAR(50) PRIMARY KEY,
+    cron               VARCHAR(100) NOT NULL,
+    enabled            BOOLEAN      NOT NULL DEFAULT TRUE,
+    params             TEXT         NOT NULL DEFAULT '',
+    last_scheduled_for TIMESTAMP WITH TIME ZONE,
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS job_runs
+(
+    id            SERIAL PRIMARY KEY,
+    job_name      VARCHAR(50) NOT NULL REFERENCES scheduled_jobs (name) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    started_at    TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at   TIMESTAMP WITH TIME ZONE,
+    status        VARCHAR(20) NOT NULL DEFAULT 'running',
+    error         TEXT,
+    UNIQUE (job_name, scheduled_for)
 );
 
 CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
 CREATE INDEX idx_orders_current ON orders (user_id, apartment, is_current);
-CREATE INDEX idx_orders_apartment ON orders (entrance, floor, apartment, is_current);
\ No newline at end of file
+CREATE INDEX idx_orders_apartment ON orders (entrance, floor, apartment, is_current);
+CREATE INDEX idx_staff_role ON staff (role);
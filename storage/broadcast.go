@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetBroadcastAudience возвращает Telegram ID получателей рассылки
+func (p *Postgres) GetBroadcastAudience(audience models.BroadcastAudience) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var query string
+	var args []interface{}
+	switch audience.Kind {
+	case models.AudienceAll:
+		query = `SELECT telegram_id FROM users`
+	case models.AudienceCustomers:
+		query = `SELECT DISTINCT user_id FROM orders WHERE user_id IS NOT NULL`
+	case models.AudienceEntrance:
+		query = `SELECT DISTINCT user_id FROM orders WHERE entrance = $1 AND is_current = true AND user_id IS NOT NULL`
+		args = append(args, audience.Entrance)
+	case models.AudienceStatus:
+		query = `SELECT DISTINCT user_id FROM orders WHERE status = $1 AND is_current = true AND user_id IS NOT NULL`
+		args = append(args, audience.Status)
+	case models.AudienceNoOrders:
+		query = `
+            SELECT DISTINCT o.user_id FROM orders o
+            WHERE o.user_id IS NOT NULL AND o.created_at < $1
+            AND NOT EXISTS (
+                SELECT 1 FROM orders n WHERE n.user_id = o.user_id AND n.created_at >= $1
+            )`
+		args = append(args, audience.SeasonStart)
+	default:
+		return nil, fmt.Errorf("неизвестная аудитория: %s", audience.Kind)
+	}
+
+	rows, err := p.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения получателей рассылки: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CreateBroadcast сохраняет рассылку вместе со списком получателей и возвращает ее ID
+func (p *Postgres) CreateBroadcast(broadcast models.Broadcast, recipients []int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось начать транзакцию: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+        INSERT INTO broadcasts (created_by, text, audience, total, created_at, claimed_at)
+        VALUES ($1, $2, $3, $4, NOW(), $5)
+        RETURNING id`,
+		broadcast.CreatedBy, broadcast.Text, broadcast.Audience, len(recipients), broadcast.ClaimedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения рассылки: %v", err)
+	}
+
+	rows := make([][]interface{}, 0, len(recipients))
+	for _, telegramID := range recipients {
+		rows = append(rows, []interface{}{id, telegramID, models.RecipientPending})
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"broadcast_recipients"},
+		[]string{"broadcast_id", "telegram_id", "status"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения получателей рассылки: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+
+	return id, nil
+}
+
+// SetBroadcastRecipientStatus сохраняет результат доставки сообщения получателю
+func (p *Postgres) SetBroadcastRecipientStatus(broadcastID, telegramID int64, status string, errText string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        UPDATE broadcast_recipients
+        SET status = $3, error = NULLIF($4, ''), sent_at = NOW()
+        WHERE broadcast_id = $1 AND telegram_id = $2`,
+		broadcastID, telegramID, status, errText)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса получателя: %v", err)
+	}
+
+	return nil
+}
+
+// FinishBroadcast подсчитывает итоги рассылки и отмечает ее завершенной
+func (p *Postgres) FinishBroadcast(broadcastID int64) (models.Broadcast, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var broadcast models.Broadcast
+	err := p.Pool.QueryRow(ctx, `
+        UPDATE broadcasts b
+        SET delivered = s.delivered, blocked = s.blocked, failed = s.failed, finished_at = NOW()
+        FROM (
+            SELECT
+                COUNT(*) FILTER (WHERE status = 'delivered') AS delivered,
+                COUNT(*) FILTER (WHERE status = 'blocked') AS blocked,
+                COUNT(*) FILTER (WHERE status = 'failed') AS failed
+            FROM broadcast_recipients
+            WHERE broadcast_id = $1
+        ) s
+        WHERE b.id = $1
+        RETURNING b.id, b.created_by, b.text, b.audience, b.total,
+            b.delivered, b.blocked, b.failed, b.created_at, b.finished_at`,
+		broadcastID).Scan(
+		&broadcast.ID, &broadcast.CreatedBy, &broadcast.Text, &broadcast.Audience, &broadcast.Total,
+		&broadcast.Delivered, &broadcast.Blocked, &broadcast.Failed, &broadcast.CreatedAt, &broadcast.FinishedAt)
+	if err != nil {
+		return broadcast, fmt.Errorf("ошибка завершения рассылки: %v", err)
+	}
+
+	return broadcast, nil
+}
+
+// ClaimUnfinishedBroadcasts отмечает поставленными в очередь в момент startedAt рассылки,
+// прерванные перезапуском бота, и возвращает их вместе с получателями, которым сообщение
+// еще не отправлялось. Рассылки, поставленные в очередь после запуска, не затрагиваются,
+// поэтому одна рассылка не отправляется дважды.
+func (p *Postgres) ClaimUnfinishedBroadcasts(startedAt time.Time) (map[int64][]int64, []models.Broadcast, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        UPDATE broadcasts
+        SET claimed_at = $1
+        WHERE finished_at IS NULL AND (claimed_at IS NULL OR claimed_at < $1)
+        RETURNING id, created_by, text, audience, total, created_at`,
+		startedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения незавершенных рассылок: %v", err)
+	}
+	var broadcasts []models.Broadcast
+	for rows.Next() {
+		var b models.Broadcast
+		if err := rows.Scan(&b.ID, &b.CreatedBy, &b.Text, &b.Audience, &b.Total, &b.CreatedAt); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		broadcasts = append(broadcasts, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения незавершенных рассылок: %v", err)
+	}
+	if len(broadcasts) == 0 {
+		return nil, nil, nil
+	}
+	sort.Slice(broadcasts, func(i, j int) bool { return broadcasts[i].ID < broadcasts[j].ID })
+
+	ids := make([]int64, len(broadcasts))
+	for i, b := range broadcasts {
+		ids[i] = b.ID
+	}
+	pending := make(map[int64][]int64)
+	rows, err = p.Pool.Query(ctx, `
+        SELECT broadcast_id, telegram_id
+        FROM broadcast_recipients
+        WHERE broadcast_id = ANY($1) AND status = 'pending'`,
+		ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения получателей рассылок: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var broadcastID, telegramID int64
+		if err := rows.Scan(&broadcastID, &telegramID); err != nil {
+			return nil, nil, err
+		}
+		pending[broadcastID] = append(pending[broadcastID], telegramID)
+	}
+
+	return pending, broadcasts, rows.Err()
+}
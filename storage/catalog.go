@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// GetItemTypes возвращает каталог изделий, включая выведенные из продажи, в порядке показа
+func (p *Postgres) GetItemTypes() ([]models.ItemType, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT code, titles, COALESCE(sash, ''), price, position, active
+        FROM item_types
+        ORDER BY position, code`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения каталога изделий: %v", err)
+	}
+	defer rows.Close()
+
+	var items []models.ItemType
+	for rows.Next() {
+		var item models.ItemType
+		if err := rows.Scan(&item.Code, &item.Titles, &item.Sash, &item.Price, &item.Position, &item.Active); err != nil {
+			return nil, fmt.Errorf("ошибка чтения каталога изделий: %v", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// GetAddonTypes возвращает каталог дополнительных услуг, включая выведенные из продажи, в порядке показа
+func (p *Postgres) GetAddonTypes() ([]models.AddonType, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT code, titles, unit, applies_to, price, position, active
+        FROM addon_types
+        ORDER BY position, code`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения каталога дополнительных услуг: %v", err)
+	}
+	defer rows.Close()
+
+	var addons []models.AddonType
+	for rows.Next() {
+		var addon models.AddonType
+		if err := rows.Scan(&addon.Code, &addon.Titles, &addon.Unit, &addon.AppliesTo,
+			&addon.Price, &addon.Position, &addon.Active); err != nil {
+			return nil, fmt.Errorf("ошибка чтения каталога дополнительных услуг: %v", err)
+		}
+		addons = append(addons, addon)
+	}
+	return addons, rows.Err()
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// ImportOrders сохраняет загруженные из файла заказы одной транзакцией.
+// Подтвержденный заказ становится актуальным для квартиры; если в ней уже есть актуальный
+// подтвержденный заказ, загружаемый не сохраняется — возвращаются индексы таких заказов в orders.
+// Заказы с другими статусами сохраняются как неактуальные и на текущие заказы не влияют.
+func (p *Postgres) ImportOrders(orders []models.Order) (duplicates []int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось начать транзакцию: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	for i, order := range orders {
+		order.Source = models.OrderSourceImport
+		if order.Status != "confirmed" {
+			order.IsCurrent = false
+			if _, err = insertOrder(ctx, tx, order); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var exists bool
+		err = tx.QueryRow(ctx, `
+            SELECT EXISTS(
+                SELECT 1 FROM orders
+                WHERE entrance = $1 AND floor = $2 AND apartment = $3
+                AND is_current = true AND status = 'confirmed'
+            )`,
+			order.Entrance, order.Floor, order.Apartment).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка проверки существующих заказов: %v", err)
+		}
+		if exists {
+			duplicates = append(duplicates, i)
+			continue
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE orders
+            SET is_current = false
+            WHERE entrance = $1 AND floor = $2 AND apartment = $3 AND is_current = true`,
+			order.Entrance, order.Floor, order.Apartment)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка деактивации предыдущих заказов: %v", err)
+		}
+
+		order.IsCurrent = true
+		if _, err = insertOrder(ctx, tx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+	return duplicates, nil
+}
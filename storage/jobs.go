@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// EnsureJob создает задачу планировщика, если ее еще нет (существующая не меняется)
+func (p *Postgres) EnsureJob(job models.ScheduledJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        INSERT INTO scheduled_jobs (name, cron, enabled, params, created_at)
+        VALUES ($1, $2, $3, $4, NOW())
+        ON CONFLICT (name) DO NOTHING`,
+		job.Name, job.Cron, job.Enabled, job.Params)
+	if err != nil {
+		return fmt.Errorf("ошибка создания задачи %s: %v", job.Name, err)
+	}
+
+	return nil
+}
+
+// GetJobs возвращает все задачи планировщика
+func (p *Postgres) GetJobs() ([]models.ScheduledJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT name, cron, enabled, params, last_scheduled_for, created_at
+        FROM scheduled_jobs
+        ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения задач: %v", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.ScheduledJob
+	for rows.Next() {
+		var job models.ScheduledJob
+		var last *time.Time
+		if err := rows.Scan(&job.Name, &job.Cron, &job.Enabled, &job.Params, &last, &job.CreatedAt); err != nil {
+			return nil, err
+		}
+		if last != nil {
+			job.LastScheduledFor = *last
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// UpdateJob сохраняет расписание, параметры и признак включения задачи
+func (p *Postgres) UpdateJob(job models.ScheduledJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tag, err := p.Pool.Exec(ctx, `
+        UPDATE scheduled_jobs
+        SET cron = $2, enabled = $3, params = $4
+        WHERE name = $1`,
+		job.Name, job.Cron, job.Enabled, job.Params)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления задачи: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("задача %s не найдена", job.Name)
+	}
+
+	return nil
+}
+
+// SetJobScheduledFor запоминает последний обработанный запуск по расписанию
+func (p *Postgres) SetJobScheduledFor(name string, scheduledFor time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        UPDATE scheduled_jobs SET last_scheduled_for = $2
+        WHERE name = $1 AND (last_scheduled_for IS NULL OR last_scheduled_for < $2)`,
+		name, scheduledFor)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления задачи: %v", err)
+	}
+
+	return nil
+}
+
+// ClaimJobRun регистрирует запуск задачи на указанное время.
+// Возвращает false, если этот запуск уже был выполнен (например, до перезапуска бота).
+func (p *Postgres) ClaimJobRun(name string, scheduledFor time.Time) (int64, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var id int64
+	err := p.Pool.QueryRow(ctx, `
+        INSERT INTO job_runs (job_name, scheduled_for, started_at, status)
+        VALUES ($1, $2, NOW(), 'running')
+        ON CONFLICT (job_name, scheduled_for) DO NOTHING
+        RETURNING id`,
+		name, scheduledFor).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("ошибка регистрации запуска задачи: %v", err)
+	}
+
+	return id, true, nil
+}
+
+// FinishJobRun сохраняет результат запуска задачи
+func (p *Postgres) FinishJobRun(runID int64, runErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status, errText := models.JobRunSuccess, ""
+	if runErr != nil {
+		status, errText = models.JobRunFailed, runErr.Error()
+	}
+
+	_, err := p.Pool.Exec(ctx, `
+        UPDATE job_runs
+        SET finished_at = NOW(), status = $2, error = NULLIF($3, '')
+        WHERE id = $1`,
+		runID, status, errText)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения результата задачи: %v", err)
+	}
+
+	return nil
+}
+
+// GetJobRuns возвращает последние запуски задачи
+func (p *Postgres) GetJobRuns(name string, limit int) ([]models.JobRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT id, job_name, scheduled_for, started_at, finished_at, status, COALESCE(error, '')
+        FROM job_runs
+        WHERE job_name = $1
+        ORDER BY scheduled_for DESC
+        LIMIT $2`,
+		name, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории задачи: %v", err)
+	}
+	defer rows.Close()
+
+	var runs []models.JobRun
+	for rows.Next() {
+		var run models.JobRun
+		var finished *time.Time
+		if err := rows.Scan(&run.ID, &run.JobName, &run.ScheduledFor, &run.StartedAt, &finished, &run.Status, &run.Error); err != nil {
+			return nil, err
+		}
+		if finished != nil {
+			run.FinishedAt = *finished
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// GetWorkPlanOrders возвращает актуальные подтвержденные заказы, подтвержденные в интервале
+// [from, to), в порядке обхода дома. Время подтверждения берется из истории статусов, поэтому
+// заказ, подтвержденный после уточнения, попадает в план в день подтверждения.
+func (p *Postgres) GetWorkPlanOrders(from, to time.Time) ([]models.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, orderSelect+`
+        WHERE o.is_current = true AND o.status = 'confirmed'
+        AND EXISTS(
+            SELECT 1 FROM order_status_history h
+            WHERE h.order_id = o.id AND h.status = 'confirmed'
+            AND h.changed_at >= $1 AND h.changed_at < $2
+        )
+        ORDER BY o.entrance, o.floor, LPAD(o.apartment, 5, '0')`,
+		from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения плана работ: %v", err)
+	}
+	defer rows.Close()
+
+	var orders []models.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := p.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -2,12 +2,16 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/eugenepelipets/window-wash-bot/clock"
 	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -22,7 +26,14 @@ func NewPostgres() (*Postgres, error) {
 		log.Fatal("❌ Переменная DATABASE_URL не задана! Проверь .env")
 	}
 
-	pool, err := pgxpool.New(context.Background(), dbURL)
+	config, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	// Даты в SQL (NOW(), приведение к date) считаются в часовом поясе развертывания
+	config.ConnConfig.RuntimeParams["timezone"] = clock.Location().String()
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +56,15 @@ func (p *Postgres) SaveUser(user models.User) error {
 	defer cancel()
 
 	query := `
-		INSERT INTO users (telegram_id, username, first_name, last_name, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (telegram_id, username, first_name, last_name, language, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW())
 		ON CONFLICT (telegram_id) DO UPDATE
-		SET username = EXCLUDED.username, first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name;
+		SET username = EXCLUDED.username, first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name,
+		    language = COALESCE(users.language, EXCLUDED.language);
 	`
 
-	_, err := p.Pool.Exec(ctx, query, user.TelegramID, user.UserName, user.FirstName, user.LastName, time.Now())
+	_, err := p.Pool.Exec(ctx, query, user.TelegramID, user.UserName, user.FirstName, user.LastName,
+		user.Language)
 	if err != nil {
 		log.Printf("⚠️ Ошибка при сохранении пользователя: %v", err)
 		return err
@@ -60,6 +73,63 @@ func (p *Postgres) SaveUser(user models.User) error {
 	return nil
 }
 
+// GetUser возвращает пользователя по Telegram ID или nil, если он не найден
+func (p *Postgres) GetUser(telegramID int64) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	err := p.Pool.QueryRow(ctx, `
+        SELECT id, telegram_id, username, first_name, last_name, COALESCE(phone, ''),
+               COALESCE(language, ''), created_at
+        FROM users
+        WHERE telegram_id = $1`,
+		telegramID).Scan(&user.ID, &user.TelegramID, &user.UserName, &user.FirstName,
+		&user.LastName, &user.Phone, &user.Language, &user.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения пользователя: %v", err)
+	}
+
+	return &user, nil
+}
+
+// SetUserPhone сохраняет телефон в профиле пользователя
+func (p *Postgres) SetUserPhone(telegramID int64, phone string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        INSERT INTO users (telegram_id, username, first_name, last_name, phone, created_at)
+        VALUES ($1, '', '', '', $2, NOW())
+        ON CONFLICT (telegram_id) DO UPDATE SET phone = EXCLUDED.phone`,
+		telegramID, phone)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения телефона: %v", err)
+	}
+
+	return nil
+}
+
+// SetUserLanguage сохраняет выбранный пользователем язык интерфейса
+func (p *Postgres) SetUserLanguage(telegramID int64, language string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        INSERT INTO users (telegram_id, username, first_name, last_name, language, created_at)
+        VALUES ($1, '', '', '', $2, NOW())
+        ON CONFLICT (telegram_id) DO UPDATE SET language = EXCLUDED.language`,
+		telegramID, language)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения языка: %v", err)
+	}
+
+	return nil
+}
+
 // Сохранение заказа
 func (p *Postgres) SaveOrder(order models.Order) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -75,20 +145,6 @@ func (p *Postgres) SaveOrder(order models.Order) error {
 		}
 	}()
 
-	// Определяем тип окон для сохранения в БД
-	windowType := "different"
-	if order.WindowsSame {
-		if order.Window3Count > 0 {
-			windowType = "3_same"
-		} else if order.Window4Count > 0 {
-			windowType = "4_same"
-		} else if order.Window5Count > 0 {
-			windowType = "5_same"
-		} else if order.Window6_7Count > 0 {
-			windowType = "6_7_same"
-		}
-	}
-
 	// Проверяем существующие заказы
 	var existingOrderID int64
 	err = tx.QueryRow(ctx, `
@@ -117,68 +173,198 @@ func (p *Postgres) SaveOrder(order models.Order) error {
 		}
 	}
 
-	// Сохраняем заказ с явным указанием window_type
-	_, err = tx.Exec(ctx, `
+	if order.Source == "" {
+		order.Source = models.OrderSourceBot
+	}
+
+	if _, err = insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+
+	return nil
+}
+
+// insertOrder добавляет заказ в рамках транзакции и возвращает его номер.
+// Заказы, принятые по телефону или загруженные из файла, не привязаны к пользователю Telegram;
+// если дата создания не указана, используется текущее время.
+func insertOrder(ctx context.Context, tx pgx.Tx, order models.Order) (int64, error) {
+	var createdAt *time.Time
+	if !order.CreatedAt.IsZero() {
+		createdAt = &order.CreatedAt
+	}
+
+	var orderID int64
+	err := tx.QueryRow(ctx, `
         INSERT INTO orders (
             user_id, entrance, floor, apartment, windows_same,
-            window_3_count, window_4_count, window_5_count, window_6_7_count,
-            balcony_count, balcony_type, balcony_sash, telegram_nick,
-            price, status, is_current, created_at, window_type
+            telegram_nick, price, status, is_current, created_at,
+            source, customer_name, customer_phone, created_by, description,
+            comment, intercom_code, presence_required, preferred_time
         ) VALUES (
-            $1, $2, $3, $4, $5, $6, $7, $8, $9,
-            $10, $11, $12, $13, $14, $15, $16, NOW(), $17
-        )`,
+            NULLIF($1, 0), $2, $3, $4, $5,
+            $6, $7, $8, $9, COALESCE($14, NOW()),
+            $10, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, 0), NULLIF($15, ''),
+            NULLIF($16, ''), NULLIF($17, ''), $18, NULLIF($19, '')
+        )
+        RETURNING id`,
 		order.UserID,
 		order.Entrance,
 		order.Floor,
 		order.Apartment,
 		order.WindowsSame,
-		order.Window3Count,
-		order.Window4Count,
-		order.Window5Count,
-		order.Window6_7Count,
-		order.BalconyCount,
-		order.BalconyType,
-		order.BalconySash,
 		order.TelegramNick,
 		order.Price,
 		order.Status,
 		order.IsCurrent,
-		windowType)
-
+		order.Source,
+		order.CustomerName,
+		order.CustomerPhone,
+		order.CreatedBy,
+		createdAt,
+		order.Description,
+		order.Comment,
+		order.IntercomCode,
+		order.Presence,
+		order.PreferredTime).Scan(&orderID)
 	if err != nil {
-		return fmt.Errorf("ошибка сохранения заказа: %v", err)
+		return 0, fmt.Errorf("ошибка сохранения заказа: %v", err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("ошибка коммита транзакции: %v", err)
+	for _, item := range order.Items {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_items (order_id, item_type, quantity, unit_price)
+            VALUES ($1, $2, $3, $4)`,
+			orderID, item.ItemType, item.Quantity, item.UnitPrice)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка сохранения позиций заказа: %v", err)
+		}
 	}
 
-	return nil
+	for _, loggia := range order.Loggias {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_loggias (order_id, loggia_type, sash, glazing)
+            VALUES ($1, $2, $3, NULLIF($4, ''))`,
+			orderID, loggia.Type, loggia.Sash, loggia.Glazing)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка сохранения лоджий заказа: %v", err)
+		}
+	}
+
+	for _, fileID := range order.Photos {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_photos (order_id, file_id)
+            VALUES ($1, $2)`,
+			orderID, fileID)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка сохранения фото заказа: %v", err)
+		}
+	}
+
+	for _, addon := range order.Addons {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_addons (order_id, addon_type, quantity, unit_price)
+            VALUES ($1, $2, $3, $4)`,
+			orderID, addon.AddonType, addon.Quantity, addon.UnitPrice)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка сохранения дополнительных услуг заказа: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO order_status_history (order_id, status, changed_by, changed_at)
+        SELECT id, status, created_by, created_at FROM orders WHERE id = $1`,
+		orderID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения истории статусов: %v", err)
+	}
+	return orderID, nil
 }
 
-// storage/postgres.go
-// Добавляем в конец файла
+// orderSelect — общий список колонок заказа с данными пользователя
+const orderSelect = `
+        SELECT
+            o.id, COALESCE(o.user_id, 0), o.entrance, o.floor, o.apartment, o.windows_same, o.telegram_nick,
+            o.price, o.status, o.is_current, o.created_at,
+            o.source, COALESCE(o.customer_name, ''), COALESCE(o.customer_phone, ''), COALESCE(o.created_by, 0),
+            COALESCE(u.telegram_id, 0), COALESCE(u.username, ''), COALESCE(u.first_name, ''), COALESCE(u.last_name, ''),
+            COALESCE(u.phone, ''), COALESCE(o.description, ''),
+            COALESCE(o.comment, ''), COALESCE(o.intercom_code, ''), o.presence_required, COALESCE(o.preferred_time, '')
+        FROM orders o
+        LEFT JOIN users u ON o.user_id = u.telegram_id`
+
+// scanOrder читает строку, выбранную запросом orderSelect
+func scanOrder(row pgx.Row) (models.Order, error) {
+	var order models.Order
+	var user models.User
+	err := row.Scan(
+		&order.ID,
+		&order.UserID,
+		&order.Entrance,
+		&order.Floor,
+		&order.Apartment,
+		&order.WindowsSame,
+		&order.TelegramNick,
+		&order.Price,
+		&order.Status,
+		&order.IsCurrent,
+		&order.CreatedAt,
+		&order.Source,
+		&order.CustomerName,
+		&order.CustomerPhone,
+		&order.CreatedBy,
+		&user.TelegramID,
+		&user.UserName,
+		&user.FirstName,
+		&user.LastName,
+		&user.Phone,
+		&order.Description,
+		&order.Comment,
+		&order.IntercomCode,
+		&order.Presence,
+		&order.PreferredTime,
+	)
+	order.User = user
+	return order, err
+}
 
-// GetOrdersForExport получает заказы для экспорта (с фильтром по актуальности)
-func (p *Postgres) GetOrdersForExport(onlyCurrent bool) ([]models.Order, error) {
+// GetOrdersForExport получает заказы для экспорта с учетом фильтров
+func (p *Postgres) GetOrdersForExport(filter models.ExportFilter) ([]models.Order, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	query := `
-        SELECT 
-            o.id, o.entrance, o.floor, o.apartment, o.windows_same,
-            o.window_3_count, o.window_4_count, o.window_5_count, o.window_6_7_count,
-            o.balcony_count, o.balcony_type, o.balcony_sash, o.telegram_nick,
-            o.price, o.status, o.is_current, o.created_at,
-            u.telegram_id, u.username, u.first_name, u.last_name
-        FROM orders o
-        JOIN users u ON o.user_id = u.telegram_id
-        WHERE $1 = false OR o.is_current = true
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.OnlyCurrent {
+		conditions = append(conditions, "o.is_current = true")
+	}
+	if !filter.From.IsZero() {
+		addCondition("o.created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("o.created_at < $%d", filter.To)
+	}
+	if filter.Entrance != 0 {
+		addCondition("o.entrance = $%d", filter.Entrance)
+	}
+	if filter.Status != "" {
+		addCondition("o.status = $%d", filter.Status)
+	}
+
+	query := orderSelect + `
+        WHERE ` + strings.Join(conditions, " AND ") + `
         ORDER BY o.created_at DESC
     `
 
-	rows, err := p.Pool.Query(ctx, query, onlyCurrent)
+	rows, err := p.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -186,41 +372,126 @@ func (p *Postgres) GetOrdersForExport(onlyCurrent bool) ([]models.Order, error)
 
 	var orders []models.Order
 	for rows.Next() {
-		var order models.Order
-		var user models.User
-		err := rows.Scan(
-			&order.ID,
-			&order.Entrance,
-			&order.Floor,
-			&order.Apartment,
-			&order.WindowsSame,
-			&order.Window3Count,
-			&order.Window4Count,
-			&order.Window5Count,
-			&order.Window6_7Count,
-			&order.BalconyCount,
-			&order.BalconyType,
-			&order.BalconySash,
-			&order.TelegramNick,
-			&order.Price,
-			&order.Status,
-			&order.IsCurrent,
-			&order.CreatedAt,
-			&user.TelegramID,
-			&user.UserName,
-			&user.FirstName,
-			&user.LastName,
-		)
+		order, err := scanOrder(rows)
 		if err != nil {
 			return nil, err
 		}
-		order.User = user
 		orders = append(orders, order)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	if err := p.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
 	return orders, nil
 }
 
+// loadOrderDetails заполняет позиции, дополнительные услуги, фото и лоджии заказов, выбранных запросом orderSelect
+func (p *Postgres) loadOrderDetails(ctx context.Context, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(orders))
+	index := make(map[int64]int, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		index[order.ID] = i
+	}
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT id, order_id, item_type, quantity, unit_price
+        FROM order_items
+        WHERE order_id = ANY($1)
+        ORDER BY order_id, id`,
+		ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения позиций заказов: %v", err)
+	}
+	for rows.Next() {
+		var item models.OrderItem
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ItemType, &item.Quantity, &item.UnitPrice); err != nil {
+			rows.Close()
+			return fmt.Errorf("ошибка чтения позиций заказов: %v", err)
+		}
+		i := index[item.OrderID]
+		orders[i].Items = append(orders[i].Items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ошибка чтения позиций заказов: %v", err)
+	}
+
+	rows, err = p.Pool.Query(ctx, `
+        SELECT id, order_id, addon_type, quantity, unit_price
+        FROM order_addons
+        WHERE order_id = ANY($1)
+        ORDER BY order_id, id`,
+		ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения дополнительных услуг заказов: %v", err)
+	}
+	for rows.Next() {
+		var addon models.OrderAddon
+		if err := rows.Scan(&addon.ID, &addon.OrderID, &addon.AddonType, &addon.Quantity, &addon.UnitPrice); err != nil {
+			rows.Close()
+			return fmt.Errorf("ошибка чтения дополнительных услуг заказов: %v", err)
+		}
+		i := index[addon.OrderID]
+		orders[i].Addons = append(orders[i].Addons, addon)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ошибка чтения дополнительных услуг заказов: %v", err)
+	}
+
+	rows, err = p.Pool.Query(ctx, `
+        SELECT order_id, file_id
+        FROM order_photos
+        WHERE order_id = ANY($1)
+        ORDER BY order_id, id`,
+		ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения фото заказов: %v", err)
+	}
+	for rows.Next() {
+		var orderID int64
+		var fileID string
+		if err := rows.Scan(&orderID, &fileID); err != nil {
+			rows.Close()
+			return fmt.Errorf("ошибка чтения фото заказов: %v", err)
+		}
+		i := index[orderID]
+		orders[i].Photos = append(orders[i].Photos, fileID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ошибка чтения фото заказов: %v", err)
+	}
+
+	rows, err = p.Pool.Query(ctx, `
+        SELECT id, order_id, loggia_type, sash, COALESCE(glazing, '')
+        FROM order_loggias
+        WHERE order_id = ANY($1)
+        ORDER BY order_id, id`,
+		ids)
+	if err != nil {
+		return fmt.Errorf("ошибка получения лоджий заказов: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var loggia models.Loggia
+		if err := rows.Scan(&loggia.ID, &loggia.OrderID, &loggia.Type, &loggia.Sash, &loggia.Glazing); err != nil {
+			return fmt.Errorf("ошибка чтения лоджий заказов: %v", err)
+		}
+		i := index[loggia.OrderID]
+		orders[i].Loggias = append(orders[i].Loggias, loggia)
+	}
+	return rows.Err()
+}
+
 // CheckExistingOrder проверяет наличие активных заказов для указанной квартиры
 func (p *Postgres) CheckExistingOrder(entrance int, floor int, apartment string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
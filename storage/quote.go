@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// ErrQuoteOutdated — заявка уже обработана или цена изменилась после предложения клиенту
+var ErrQuoteOutdated = errors.New("предложение по заявке устарело")
+
+// SaveQuoteRequest сохраняет заявку на расчет нестандартных окон и возвращает ее номер.
+// Пока клиент не принял цену, заявка не считается актуальным заказом квартиры.
+func (p *Postgres) SaveQuoteRequest(order models.Order) (orderID int64, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось начать транзакцию: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	order.Status = models.StatusAwaitingQuote
+	order.IsCurrent = false
+	if order.Source == "" {
+		order.Source = models.OrderSourceBot
+	}
+	if orderID, err = insertOrder(ctx, tx, order); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+	return orderID, nil
+}
+
+// AcceptQuote оформляет заявку, цену которой принял клиент, и возвращает новый статус.
+// Как и при сохранении заказа, при подтвержденном заказе на ту же квартиру заявка
+// ставится на уточнение, иначе подтверждается и становится актуальной.
+// price — цена из принятого предложения; если заявка уже обработана или цена с тех пор
+// изменилась, возвращается ErrQuoteOutdated.
+func (p *Postgres) AcceptQuote(orderID int64, price int, changedBy int64) (status string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("не удалось начать транзакцию: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	var entrance, floor int
+	var apartment, oldStatus string
+	var oldPrice int
+	err = tx.QueryRow(ctx, `
+        SELECT entrance, floor, apartment, status, price FROM orders WHERE id = $1 FOR UPDATE`,
+		orderID).Scan(&entrance, &floor, &apartment, &oldStatus, &oldPrice)
+	if err != nil {
+		return "", fmt.Errorf("ошибка получения заявки: %v", err)
+	}
+	if oldStatus != models.StatusAwaitingQuote || oldPrice != price {
+		return "", ErrQuoteOutdated
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `
+        SELECT EXISTS(
+            SELECT 1 FROM orders
+            WHERE entrance = $1 AND floor = $2 AND apartment = $3
+            AND is_current = true AND status = 'confirmed' AND id <> $4
+        )`,
+		entrance, floor, apartment, orderID).Scan(&exists)
+	if err != nil {
+		return "", fmt.Errorf("ошибка проверки заказов: %v", err)
+	}
+
+	status = "needs_clarification"
+	if !exists {
+		status = "confirmed"
+		_, err = tx.Exec(ctx, `
+            UPDATE orders
+            SET is_current = false
+            WHERE entrance = $1 AND floor = $2 AND apartment = $3 AND is_current = true`,
+			entrance, floor, apartment)
+		if err != nil {
+			return "", fmt.Errorf("ошибка деактивации предыдущих заказов: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE orders SET status = $2, is_current = true WHERE id = $1`,
+		orderID, status)
+	if err != nil {
+		return "", fmt.Errorf("ошибка обновления заявки: %v", err)
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO order_status_history (order_id, status, changed_by, changed_at)
+        VALUES ($1, $2, NULLIF($3, 0), NOW())`,
+		orderID, status, changedBy)
+	if err != nil {
+		return "", fmt.Errorf("ошибка сохранения истории статусов: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+	return status, nil
+}
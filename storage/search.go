@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// FindOrders ищет заказы по критериям и возвращает страницу результатов и общее количество
+func (p *Postgres) FindOrders(search models.OrderSearch, limit, offset int) ([]models.Order, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if search.OrderID != 0 {
+		addCondition("o.id = $%d", search.OrderID)
+	}
+	if search.TelegramID != 0 {
+		addCondition("o.user_id = $%d", search.TelegramID)
+	}
+	if search.UserName != "" {
+		addCondition("(LOWER(u.username) = LOWER($%[1]d) OR LOWER(LTRIM(o.telegram_nick, '@')) = LOWER($%[1]d))", search.UserName)
+	}
+	if search.Entrance != 0 {
+		addCondition("o.entrance = $%d", search.Entrance)
+	}
+	if search.Floor != 0 {
+		addCondition("o.floor = $%d", search.Floor)
+	}
+	if search.Apartment != "" {
+		addCondition("o.apartment = $%d", search.Apartment)
+	}
+	if len(conditions) == 0 {
+		return nil, 0, errors.New("не заданы критерии поиска")
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	err := p.Pool.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM orders o
+        LEFT JOIN users u ON o.user_id = u.telegram_id`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета заказов: %v", err)
+	}
+
+	args = append(args, limit, offset)
+	query := orderSelect + where + fmt.Sprintf(`
+        ORDER BY o.is_current DESC, o.created_at DESC
+        LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := p.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка поиска заказов: %v", err)
+	}
+	defer rows.Close()
+
+	var orders []models.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := p.loadOrderDetails(ctx, orders); err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// GetOrderByID возвращает заказ по ID (nil, если не найден)
+func (p *Postgres) GetOrderByID(id int64) (*models.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order, err := scanOrder(p.Pool.QueryRow(ctx, orderSelect+` WHERE o.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заказа: %v", err)
+	}
+
+	orders := []models.Order{order}
+	if err := p.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// GetLastOrder возвращает последний заказ пользователя или nil, если заказов не было.
+// Заявки на расчет нестандартных окон не учитываются: повторить их нельзя.
+func (p *Postgres) GetLastOrder(userID int64) (*models.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	order, err := scanOrder(p.Pool.QueryRow(ctx, orderSelect+`
+        WHERE o.user_id = $1 AND o.description IS NULL
+        ORDER BY o.created_at DESC, o.id DESC
+        LIMIT 1`, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения последнего заказа: %v", err)
+	}
+
+	orders := []models.Order{order}
+	if err := p.loadOrderDetails(ctx, orders); err != nil {
+		return nil, err
+	}
+	return &orders[0], nil
+}
+
+// UpdateOrder сохраняет изменения адреса, стоимости и статуса заказа; смена статуса попадает в историю
+func (p *Postgres) UpdateOrder(order models.Order, changedBy int64) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("не удалось начать транзакцию: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	var oldStatus string
+	err = tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, order.ID).Scan(&oldStatus)
+	if err != nil {
+		return fmt.Errorf("ошибка получения заказа: %v", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE orders
+        SET entrance = $2, floor = $3, apartment = $4, price = $5, status = $6
+        WHERE id = $1`,
+		order.ID, order.Entrance, order.Floor, order.Apartment, order.Price, order.Status)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления заказа: %v", err)
+	}
+
+	switch {
+	case order.Status == "canceled":
+		// Отмененный заказ больше не считается заказом квартиры
+		_, err = tx.Exec(ctx, `UPDATE orders SET is_current = false WHERE id = $1`, order.ID)
+	case order.Status == "confirmed" && oldStatus != "confirmed":
+		// Подтвержденный заказ становится единственным актуальным заказом квартиры,
+		// как при сохранении нового заказа
+		_, err = tx.Exec(ctx, `
+            UPDATE orders SET is_current = (id = $4)
+            WHERE entrance = $1 AND floor = $2 AND apartment = $3 AND (is_current = true OR id = $4)`,
+			order.Entrance, order.Floor, order.Apartment, order.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка обновления актуального заказа: %v", err)
+	}
+
+	if oldStatus != order.Status {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_status_history (order_id, status, changed_by, changed_at)
+            VALUES ($1, $2, NULLIF($3, 0), NOW())`,
+			order.ID, order.Status, changedBy)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения истории статусов: %v", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ошибка коммита транзакции: %v", err)
+	}
+	return nil
+}
+
+// GetStatusHistory возвращает историю статусов заказов, сгруппированную по номеру заказа
+func (p *Postgres) GetStatusHistory(orderIDs []int64) (map[int64][]models.OrderStatusChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT order_id, status, COALESCE(changed_by, 0), changed_at
+        FROM order_status_history
+        WHERE order_id = ANY($1)
+        ORDER BY order_id, changed_at, id`,
+		orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории статусов: %v", err)
+	}
+	defer rows.Close()
+
+	history := make(map[int64][]models.OrderStatusChange)
+	for rows.Next() {
+		var change models.OrderStatusChange
+		if err := rows.Scan(&change.OrderID, &change.Status, &change.ChangedBy, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("ошибка чтения истории статусов: %v", err)
+		}
+		history[change.OrderID] = append(history[change.OrderID], change)
+	}
+	return history, rows.Err()
+}
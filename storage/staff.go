@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetStaff возвращает сотрудника по Telegram ID (nil, если не найден)
+func (p *Postgres) GetStaff(telegramID int64) (*models.Staff, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var staff models.Staff
+	err := p.Pool.QueryRow(ctx, `
+        SELECT id, telegram_id, role, COALESCE(added_by, 0), created_at
+        FROM staff
+        WHERE telegram_id = $1`,
+		telegramID).Scan(&staff.ID, &staff.TelegramID, &staff.Role, &staff.AddedBy, &staff.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сотрудника: %v", err)
+	}
+	return &staff, nil
+}
+
+// SaveStaff добавляет сотрудника или меняет его роль
+func (p *Postgres) SaveStaff(staff models.Staff) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := p.Pool.Exec(ctx, `
+        INSERT INTO staff (telegram_id, role, added_by, created_at)
+        VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (telegram_id) DO UPDATE
+        SET role = EXCLUDED.role, added_by = EXCLUDED.added_by`,
+		staff.TelegramID, staff.Role, staff.AddedBy)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения сотрудника: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteStaff удаляет сотрудника; возвращает false, если такого не было
+func (p *Postgres) DeleteStaff(telegramID int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tag, err := p.Pool.Exec(ctx, `DELETE FROM staff WHERE telegram_id = $1`, telegramID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка удаления сотрудника: %v", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetStaffByRoles возвращает сотрудников с указанными ролями (все, если роли не заданы)
+func (p *Postgres) GetStaffByRoles(roles ...string) ([]models.Staff, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query, args := staffQuery(roles)
+	rows, err := p.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сотрудников: %v", err)
+	}
+	defer rows.Close()
+
+	var staff []models.Staff
+	for rows.Next() {
+		var s models.Staff
+		if err := rows.Scan(&s.ID, &s.TelegramID, &s.Role, &s.AddedBy, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		staff = append(staff, s)
+	}
+
+	return staff, rows.Err()
+}
+
+// staffQuery строит запрос сотрудников. Фильтр по ролям добавляется, только если роли
+// заданы: пустой срез pgx передает как NULL, и условие с ANY не выбрало бы никого.
+func staffQuery(roles []string) (string, []interface{}) {
+	query := `
+        SELECT id, telegram_id, role, COALESCE(added_by, 0), created_at
+        FROM staff`
+	var args []interface{}
+	if len(roles) > 0 {
+		query += `
+        WHERE role = ANY($1)`
+		args = append(args, roles)
+	}
+	return query + `
+        ORDER BY role, created_at`, args
+}
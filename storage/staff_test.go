@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStaffQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string
+		wantWhere bool
+		wantArgs  []interface{}
+	}{
+		{name: "без ролей — все сотрудники", roles: nil},
+		{name: "пустой список ролей", roles: []string{}},
+		{name: "одна роль", roles: []string{"manager"}, wantWhere: true, wantArgs: []interface{}{[]string{"manager"}}},
+		{
+			name:      "несколько ролей",
+			roles:     []string{"admin", "washer"},
+			wantWhere: true,
+			wantArgs:  []interface{}{[]string{"admin", "washer"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := staffQuery(tt.roles)
+			if got := strings.Contains(query, "WHERE"); got != tt.wantWhere {
+				t.Errorf("WHERE in query = %v, want %v:\n%s", got, tt.wantWhere, query)
+			}
+			if strings.Count(query, "$") != len(args) {
+				t.Errorf("placeholders = %d, args = %d", strings.Count(query, "$"), len(args))
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugenepelipets/window-wash-bot/models"
+)
+
+// revenueCondition — условие для заказов, входящих в выручку и средний чек: подтвержденных
+// и актуальных. Замененные повторным заказом квартиры заказы не учитываются, как и в
+// models.Order.CountsInRevenue. alias — префикс таблицы, например "o.".
+func revenueCondition(alias string) string {
+	return alias + "status = 'confirmed' AND " + alias + "is_current = true"
+}
+
+// GetOrderStats считает статистику по заказам, созданным в интервале [from, to)
+func (p *Postgres) GetOrderStats(from, to time.Time) (models.OrderStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stats := models.OrderStats{
+		From:              from,
+		To:                to,
+		ByStatus:          make(map[string]int),
+		ByEntrance:        make(map[int]int),
+		RevenueByEntrance: make(map[int]int),
+		ItemCounts:        make(map[string]int),
+	}
+
+	// Общие показатели
+	err := p.Pool.QueryRow(ctx, `
+        SELECT
+            COUNT(*),
+            COALESCE(SUM(price) FILTER (WHERE `+revenueCondition("")+`), 0),
+            COALESCE(ROUND(AVG(price) FILTER (WHERE `+revenueCondition("")+`)), 0)::int,
+            COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM order_loggias l WHERE l.order_id = orders.id)),
+            (SELECT COUNT(*)
+             FROM order_loggias l
+             JOIN orders o ON o.id = l.order_id
+             WHERE o.created_at >= $1 AND o.created_at < $2),
+            COUNT(DISTINCT user_id),
+            (SELECT COUNT(*) FROM users WHERE created_at >= $1 AND created_at < $2)
+        FROM orders
+        WHERE created_at >= $1 AND created_at < $2`,
+		from, to).Scan(
+		&stats.TotalOrders,
+		&stats.Revenue,
+		&stats.AverageCheck,
+		&stats.OrdersWithLoggia,
+		&stats.LoggiaCount,
+		&stats.Customers,
+		&stats.NewUsers,
+	)
+	if err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики: %v", err)
+	}
+
+	// Заказы по статусам
+	rows, err := p.Pool.Query(ctx, `
+        SELECT COALESCE(status, ''), COUNT(*)
+        FROM orders
+        WHERE created_at >= $1 AND created_at < $2
+        GROUP BY status`,
+		from, to)
+	if err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по статусам: %v", err)
+	}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		stats.ByStatus[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по статусам: %v", err)
+	}
+
+	// Изделия по типам каталога
+	rows, err = p.Pool.Query(ctx, `
+        SELECT i.item_type, SUM(i.quantity)
+        FROM order_items i
+        JOIN orders o ON o.id = i.order_id
+        WHERE o.created_at >= $1 AND o.created_at < $2
+        GROUP BY i.item_type`,
+		from, to)
+	if err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по изделиям: %v", err)
+	}
+	for rows.Next() {
+		var itemType string
+		var count int
+		if err := rows.Scan(&itemType, &count); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		stats.ItemCounts[itemType] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по изделиям: %v", err)
+	}
+
+	// Заказы и выручка по подъездам
+	rows, err = p.Pool.Query(ctx, `
+        SELECT entrance, COUNT(*), COALESCE(SUM(price) FILTER (WHERE `+revenueCondition("")+`), 0)
+        FROM orders
+        WHERE created_at >= $1 AND created_at < $2
+        GROUP BY entrance
+        ORDER BY entrance`,
+		from, to)
+	if err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по подъездам: %v", err)
+	}
+	for rows.Next() {
+		var entrance, count, revenue int
+		if err := rows.Scan(&entrance, &count, &revenue); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		stats.ByEntrance[entrance] = count
+		stats.RevenueByEntrance[entrance] = revenue
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по подъездам: %v", err)
+	}
+
+	// Самые активные этажи
+	rows, err = p.Pool.Query(ctx, `
+        SELECT floor, COUNT(*) AS cnt
+        FROM orders
+        WHERE created_at >= $1 AND created_at < $2
+        GROUP BY floor
+        ORDER BY cnt DESC, floor
+        LIMIT 5`,
+		from, to)
+	if err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по этажам: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fc models.FloorCount
+		if err := rows.Scan(&fc.Floor, &fc.Orders); err != nil {
+			return stats, err
+		}
+		stats.TopFloors = append(stats.TopFloors, fc)
+	}
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("ошибка расчета статистики по этажам: %v", err)
+	}
+
+	return stats, nil
+}
+
+// GetDailyOrderCounts возвращает количество заказов и выручку по дням в интервале [from, to).
+// Дни считаются в часовом поясе from.
+func (p *Postgres) GetDailyOrderCounts(from, to time.Time) ([]models.DailyCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := p.Pool.Query(ctx, `
+        SELECT
+            days.day::date,
+            COUNT(o.id),
+            COALESCE(SUM(o.price) FILTER (WHERE `+revenueCondition("o.")+`), 0)
+        FROM generate_series(($1 AT TIME ZONE $3)::date, ($2 AT TIME ZONE $3)::date - 1, '1 day') AS days(day)
+        LEFT JOIN orders o
+            ON (o.created_at AT TIME ZONE $3)::date = days.day::date
+            AND o.created_at >= $1 AND o.created_at < $2
+        GROUP BY days.day
+        ORDER BY days.day`,
+		from, to, from.Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета заказов по дням: %v", err)
+	}
+	defer rows.Close()
+
+	var counts []models.DailyCount
+	for rows.Next() {
+		var dc models.DailyCount
+		if err := rows.Scan(&dc.Day, &dc.Orders, &dc.Revenue); err != nil {
+			return nil, err
+		}
+		counts = append(counts, dc)
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,233 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadFirstSheet читает значения ячеек первого листа книги.
+// Числа и даты возвращаются как записаны в файле (даты — серийными номерами Excel,
+// см. DateFromSerial), пропущенные ячейки — пустыми строками.
+func ReadFirstSheet(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("файл не является книгой Excel: %v", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("в книге нет листа %s", sheetPath)
+	}
+	return readSheet(f, shared)
+}
+
+// DateFromSerial переводит серийный номер даты Excel во время в часовом поясе loc
+func DateFromSerial(serial float64, loc *time.Location) time.Time {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	wall := epoch.Add(time.Duration(serial * 24 * float64(time.Hour))).Round(time.Second)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+}
+
+// maxPartSize — предельный размер распакованной части книги. Размер из заголовка архива
+// может быть подделан, поэтому чтение дополнительно ограничивается этим же значением.
+const maxPartSize = 50 << 20
+
+// maxRows и maxColumns ограничивают номера строк и столбцов, чтобы ссылка вида r="1000000000"
+// не заставляла выделять память под пустые строки
+const (
+	maxRows    = 100000
+	maxColumns = 1000
+)
+
+func decodeXML(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > maxPartSize {
+		return fmt.Errorf("часть книги %s слишком большая", f.Name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := xml.NewDecoder(io.LimitReader(r, maxPartSize)).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("ошибка разбора %s: %v", f.Name, err)
+	}
+	return nil
+}
+
+// firstSheetPath находит файл первого листа через workbook.xml и его связи
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("в книге нет xl/workbook.xml")
+	}
+	var wb struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXML(wbFile, &wb); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(wb.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// richText — строка, которая может быть записана целиком или частями с форматированием
+type richText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.R) == 0 {
+		return t.T
+	}
+	var s strings.Builder
+	for _, r := range t.R {
+		s.WriteString(r.T)
+	}
+	return s.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := decodeXML(f, &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.String()
+	}
+	return shared, nil
+}
+
+func readSheet(f *zip.File, shared []string) ([][]string, error) {
+	var ws struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXML(f, &ws); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range ws.Rows {
+		// Пустые строки в файле могут отсутствовать — восстанавливаем нумерацию
+		index := len(rows)
+		if row.R > 0 {
+			index = row.R - 1
+		}
+		if index >= maxRows {
+			return nil, fmt.Errorf("в листе больше %d строк", maxRows)
+		}
+		for len(rows) <= index {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for _, c := range row.Cells {
+			col := len(values)
+			if c.Ref != "" {
+				if parsed, ok := columnIndex(c.Ref); ok {
+					col = parsed
+				}
+			}
+			if col < 0 || col >= maxColumns {
+				return nil, fmt.Errorf("в листе больше %d столбцов", maxColumns)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch c.Type {
+			case "s":
+				i, err := strconv.Atoi(c.Value)
+				if err != nil || i < 0 || i >= len(shared) {
+					return nil, fmt.Errorf("некорректная ссылка на строку в ячейке %s", c.Ref)
+				}
+				values[col] = shared[i]
+			case "inlineStr":
+				values[col] = c.Inline.String()
+			default:
+				values[col] = c.Value
+			}
+		}
+		rows[index] = values
+	}
+	return rows, nil
+}
+
+// columnIndex возвращает номер столбца (с 0) по ссылке на ячейку вида "AB12"
+func columnIndex(ref string) (int, bool) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return col - 1, true
+}
@@ -0,0 +1,365 @@
+// Package xlsx формирует простые книги Excel (.xlsx) без внешних зависимостей:
+// текстовые, числовые, денежные и датовые ячейки, жирный заголовок,
+// закрепленная первая строка и автофильтр. Умеет также читать значения
+// первого листа загруженных книг.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Стили ячеек (индексы в cellXfs файла styles.xml)
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	styleMoney   = 2
+	styleDate    = 3
+	styleInteger = 4
+)
+
+type cellKind int
+
+const (
+	kindEmpty cellKind = iota
+	kindText
+	kindNumber
+)
+
+// Cell — значение одной ячейки
+type Cell struct {
+	kind  cellKind
+	text  string
+	num   float64
+	style int
+}
+
+// Text создает текстовую ячейку
+func Text(s string) Cell {
+	return Cell{kind: kindText, text: s, style: styleDefault}
+}
+
+// Header создает ячейку заголовка (жирный шрифт, заливка)
+func Header(s string) Cell {
+	return Cell{kind: kindText, text: s, style: styleHeader}
+}
+
+// Int создает целочисленную ячейку
+func Int(n int) Cell {
+	return Cell{kind: kindNumber, num: float64(n), style: styleInteger}
+}
+
+// Money создает денежную ячейку в рублях
+func Money(n int) Cell {
+	return Cell{kind: kindNumber, num: float64(n), style: styleMoney}
+}
+
+// Date создает ячейку с датой и временем (в часовом поясе t)
+func Date(t time.Time) Cell {
+	if t.IsZero() {
+		return Cell{}
+	}
+	return Cell{kind: kindNumber, num: excelSerial(t), style: styleDate}
+}
+
+// Empty создает пустую ячейку
+func Empty() Cell {
+	return Cell{}
+}
+
+// Sheet — лист книги
+type Sheet struct {
+	Name         string
+	FreezeHeader bool // закрепить первую строку
+	AutoFilter   bool // включить автофильтр по первой строке
+	rows         [][]Cell
+	widths       map[int]float64
+}
+
+// AddRow добавляет строку в конец листа
+func (s *Sheet) AddRow(cells ...Cell) {
+	s.rows = append(s.rows, cells)
+}
+
+// SetColumnWidth задает ширину столбца (нумерация с 0) в символах
+func (s *Sheet) SetColumnWidth(col int, width float64) {
+	s.widths[col] = width
+}
+
+// Workbook — книга Excel
+type Workbook struct {
+	sheets []*Sheet
+}
+
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet добавляет лист. Запрещенные в Excel символы заменяются,
+// имя обрезается до 31 символа.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	sheet := &Sheet{Name: name, widths: make(map[int]float64)}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// Bytes упаковывает книгу в формат .xlsx
+func (w *Workbook) Bytes() ([]byte, error) {
+	if len(w.sheets) == 0 {
+		return nil, fmt.Errorf("книга не содержит листов")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name  string
+		write func(io.Writer)
+	}{
+		{"[Content_Types].xml", w.writeContentTypes},
+		{"_rels/.rels", writeRootRels},
+		{"xl/workbook.xml", w.writeWorkbook},
+		{"xl/_rels/workbook.xml.rels", w.writeWorkbookRels},
+		{"xl/styles.xml", writeStyles},
+	}
+	for i, sheet := range w.sheets {
+		sheet, index := sheet, i
+		files = append(files, struct {
+			name  string
+			write func(io.Writer)
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", index+1), sheet.write})
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания %s: %v", f.name, err)
+		}
+		f.write(fw)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("ошибка упаковки xlsx: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+func (w *Workbook) writeContentTypes(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	io.WriteString(out, `<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	io.WriteString(out, `<Default Extension="xml" ContentType="application/xml"/>`)
+	io.WriteString(out, `<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	io.WriteString(out, `<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(out, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	io.WriteString(out, `</Types>`)
+}
+
+func writeRootRels(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	io.WriteString(out, `<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>`)
+	io.WriteString(out, `</Relationships>`)
+}
+
+func (w *Workbook) writeWorkbook(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	io.WriteString(out, `<sheets>`)
+	for i, sheet := range w.sheets {
+		fmt.Fprintf(out, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	io.WriteString(out, `</sheets>`)
+
+	// Excel хранит диапазон автофильтра еще и как скрытое имя
+	var names bytes.Buffer
+	for i, sheet := range w.sheets {
+		if ref := sheet.filterRef(); ref != "" {
+			fmt.Fprintf(&names, `<definedName name="_xlnm._FilterDatabase" localSheetId="%d" hidden="1">'%s'!%s</definedName>`,
+				i, escape(quoteSheetName(sheet.Name)), absoluteRef(ref))
+		}
+	}
+	if names.Len() > 0 {
+		io.WriteString(out, `<definedNames>`)
+		out.Write(names.Bytes())
+		io.WriteString(out, `</definedNames>`)
+	}
+	io.WriteString(out, `</workbook>`)
+}
+
+func (w *Workbook) writeWorkbookRels(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		fmt.Fprintf(out, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(out, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	io.WriteString(out, `</Relationships>`)
+}
+
+func writeStyles(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	io.WriteString(out, `<numFmts count="2">`)
+	io.WriteString(out, `<numFmt numFmtId="164" formatCode="#,##0&quot; ₽&quot;"/>`)
+	io.WriteString(out, `<numFmt numFmtId="165" formatCode="dd.mm.yyyy hh:mm"/>`)
+	io.WriteString(out, `</numFmts>`)
+	io.WriteString(out, `<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>`)
+	io.WriteString(out, `<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`)
+	io.WriteString(out, `<fill><patternFill patternType="solid"><fgColor rgb="FFDDEBF7"/><bgColor indexed="64"/></patternFill></fill></fills>`)
+	io.WriteString(out, `<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	io.WriteString(out, `<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	io.WriteString(out, `<cellXfs count="5">`)
+	io.WriteString(out, `<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>`)
+	io.WriteString(out, `<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>`)
+	io.WriteString(out, `<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`)
+	io.WriteString(out, `<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`)
+	io.WriteString(out, `<xf numFmtId="1" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`)
+	io.WriteString(out, `</cellXfs>`)
+	io.WriteString(out, `<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	io.WriteString(out, `</styleSheet>`)
+}
+
+func (s *Sheet) write(out io.Writer) {
+	io.WriteString(out, xmlHeader)
+	io.WriteString(out, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if s.FreezeHeader {
+		io.WriteString(out, `<sheetViews><sheetView workbookViewId="0">`)
+		io.WriteString(out, `<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+		io.WriteString(out, `<selection pane="bottomLeft" activeCell="A2" sqref="A2"/>`)
+		io.WriteString(out, `</sheetView></sheetViews>`)
+	}
+
+	if len(s.widths) > 0 {
+		io.WriteString(out, `<cols>`)
+		for col := 0; col < s.columnCount(); col++ {
+			if width, ok := s.widths[col]; ok {
+				fmt.Fprintf(out, `<col min="%d" max="%d" width="%s" customWidth="1"/>`,
+					col+1, col+1, strconv.FormatFloat(width, 'f', -1, 64))
+			}
+		}
+		io.WriteString(out, `</cols>`)
+	}
+
+	io.WriteString(out, `<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(out, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := CellRef(c, r)
+			switch cell.kind {
+			case kindText:
+				fmt.Fprintf(out, `<c r="%s" t="inlineStr" s="%d"><is><t xml:space="preserve">%s</t></is></c>`,
+					ref, cell.style, escape(cell.text))
+			case kindNumber:
+				fmt.Fprintf(out, `<c r="%s" s="%d"><v>%s</v></c>`,
+					ref, cell.style, strconv.FormatFloat(cell.num, 'f', -1, 64))
+			}
+		}
+		io.WriteString(out, `</row>`)
+	}
+	io.WriteString(out, `</sheetData>`)
+
+	if ref := s.filterRef(); ref != "" {
+		fmt.Fprintf(out, `<autoFilter ref="%s"/>`, ref)
+	}
+	io.WriteString(out, `</worksheet>`)
+}
+
+// filterRef возвращает диапазон автофильтра ("" — фильтр не нужен)
+func (s *Sheet) filterRef() string {
+	if !s.AutoFilter || len(s.rows) == 0 {
+		return ""
+	}
+	cols := s.columnCount()
+	if cols == 0 {
+		return ""
+	}
+	return CellRef(0, 0) + ":" + CellRef(cols-1, len(s.rows)-1)
+}
+
+func (s *Sheet) columnCount() int {
+	count := 0
+	for _, row := range s.rows {
+		if len(row) > count {
+			count = len(row)
+		}
+	}
+	for col := range s.widths {
+		if col+1 > count {
+			count = col + 1
+		}
+	}
+	return count
+}
+
+// CellRef возвращает адрес ячейки в формате A1 (нумерация с 0)
+func CellRef(col, row int) string {
+	return ColumnName(col) + strconv.Itoa(row+1)
+}
+
+// ColumnName возвращает буквенное имя столбца: 0 → A, 25 → Z, 26 → AA
+func ColumnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// absoluteRef превращает "A1:C10" в "$A$1:$C$10"
+func absoluteRef(ref string) string {
+	var buf bytes.Buffer
+	prevLetter := false
+	for i, c := range ref {
+		isLetter := c >= 'A' && c <= 'Z'
+		isDigit := c >= '0' && c <= '9'
+		if isLetter && (i == 0 || ref[i-1] == ':') {
+			buf.WriteByte('$')
+		}
+		if isDigit && prevLetter {
+			buf.WriteByte('$')
+		}
+		buf.WriteRune(c)
+		prevLetter = isLetter
+	}
+	return buf.String()
+}
+
+func quoteSheetName(name string) string {
+	return string(bytes.ReplaceAll([]byte(name), []byte("'"), []byte("''")))
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// excelSerial переводит время в серийный номер даты Excel (дни с 30.12.1899)
+func excelSerial(t time.Time) float64 {
+	// Берем "настенное" время в часовом поясе t, Excel часовых поясов не знает
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}